@@ -0,0 +1,174 @@
+// Alert ingestion over gRPC. Regenerate the Go code from the repository root with:
+//
+//	protoc --go_out=. --go_opt=module=monitor-web \
+//	  --go-grpc_out=. --go-grpc_opt=module=monitor-web alertpb/alert.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: alertpb/alert.proto
+
+package alertpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AlertIngest_IngestAlert_FullMethodName  = "/monitorweb.v1.AlertIngest/IngestAlert"
+	AlertIngest_IngestAlerts_FullMethodName = "/monitorweb.v1.AlertIngest/IngestAlerts"
+)
+
+// AlertIngestClient is the client API for AlertIngest service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AlertIngest stores alert events exactly like POST /api/alerts.
+type AlertIngestClient interface {
+	// IngestAlert stores or resolves one event. Rejections are returned as errors:
+	// InvalidArgument for invalid events and PermissionDenied for a bad ingest key.
+	IngestAlert(ctx context.Context, in *AlertEvent, opts ...grpc.CallOption) (*IngestResult, error)
+	// IngestAlerts stores or resolves each event in turn, reporting one result per event.
+	IngestAlerts(ctx context.Context, in *IngestAlertsRequest, opts ...grpc.CallOption) (*IngestAlertsResponse, error)
+}
+
+type alertIngestClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAlertIngestClient(cc grpc.ClientConnInterface) AlertIngestClient {
+	return &alertIngestClient{cc}
+}
+
+func (c *alertIngestClient) IngestAlert(ctx context.Context, in *AlertEvent, opts ...grpc.CallOption) (*IngestResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestResult)
+	err := c.cc.Invoke(ctx, AlertIngest_IngestAlert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertIngestClient) IngestAlerts(ctx context.Context, in *IngestAlertsRequest, opts ...grpc.CallOption) (*IngestAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestAlertsResponse)
+	err := c.cc.Invoke(ctx, AlertIngest_IngestAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AlertIngestServer is the server API for AlertIngest service.
+// All implementations must embed UnimplementedAlertIngestServer
+// for forward compatibility.
+//
+// AlertIngest stores alert events exactly like POST /api/alerts.
+type AlertIngestServer interface {
+	// IngestAlert stores or resolves one event. Rejections are returned as errors:
+	// InvalidArgument for invalid events and PermissionDenied for a bad ingest key.
+	IngestAlert(context.Context, *AlertEvent) (*IngestResult, error)
+	// IngestAlerts stores or resolves each event in turn, reporting one result per event.
+	IngestAlerts(context.Context, *IngestAlertsRequest) (*IngestAlertsResponse, error)
+	mustEmbedUnimplementedAlertIngestServer()
+}
+
+// UnimplementedAlertIngestServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAlertIngestServer struct{}
+
+func (UnimplementedAlertIngestServer) IngestAlert(context.Context, *AlertEvent) (*IngestResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestAlert not implemented")
+}
+func (UnimplementedAlertIngestServer) IngestAlerts(context.Context, *IngestAlertsRequest) (*IngestAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestAlerts not implemented")
+}
+func (UnimplementedAlertIngestServer) mustEmbedUnimplementedAlertIngestServer() {}
+func (UnimplementedAlertIngestServer) testEmbeddedByValue()                     {}
+
+// UnsafeAlertIngestServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AlertIngestServer will
+// result in compilation errors.
+type UnsafeAlertIngestServer interface {
+	mustEmbedUnimplementedAlertIngestServer()
+}
+
+func RegisterAlertIngestServer(s grpc.ServiceRegistrar, srv AlertIngestServer) {
+	// If the following call pancis, it indicates UnimplementedAlertIngestServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AlertIngest_ServiceDesc, srv)
+}
+
+func _AlertIngest_IngestAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AlertEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertIngestServer).IngestAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlertIngest_IngestAlert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertIngestServer).IngestAlert(ctx, req.(*AlertEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlertIngest_IngestAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertIngestServer).IngestAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlertIngest_IngestAlerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertIngestServer).IngestAlerts(ctx, req.(*IngestAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AlertIngest_ServiceDesc is the grpc.ServiceDesc for AlertIngest service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AlertIngest_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "monitorweb.v1.AlertIngest",
+	HandlerType: (*AlertIngestServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IngestAlert",
+			Handler:    _AlertIngest_IngestAlert_Handler,
+		},
+		{
+			MethodName: "IngestAlerts",
+			Handler:    _AlertIngest_IngestAlerts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "alertpb/alert.proto",
+}
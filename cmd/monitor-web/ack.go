@@ -0,0 +1,109 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// ackBatchSize bounds how many alerts one UPDATE acknowledges, keeping row locks short
+const ackBatchSize = 500
+
+// ackBulkRequest selects the firing alerts to acknowledge; at least one filter is required
+type ackBulkRequest struct {
+	HostIP    string     `json:"host_ip"`
+	EventName string     `json:"event_name"`
+	From      *time.Time `json:"from"`
+	To        *time.Time `json:"to"`
+	Actor     string     `json:"actor"`
+}
+
+// ackAlertsBulk godoc
+// @Summary Acknowledge alerts in bulk
+// @Description Acknowledges every firing alert of the module matching the filters (host_ip, event_name, from, to; at least one required), recording the actor. Resolved and already acknowledged alerts are left alone. Returns how many alerts were acknowledged.
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param request body ackBulkRequest true "Filters and actor; from and to are RFC 3339 timestamps"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/ack-bulk [post]
+func ackAlertsBulk(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+
+	var req ackBulkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.Warn("Failed to parse bulk ack request", "error", err, "client_ip", c.ClientIP(), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+		return
+	}
+	if req.Actor == "" {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Missing actor"})
+		return
+	}
+	if req.HostIP == "" && req.EventName == "" && req.From == nil && req.To == nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "At least one filter is required"})
+		return
+	}
+
+	matching := func() *gorm.DB {
+		// Select on the primary so each batch sees the previous batch's updates
+		query := db.Clauses(dbresolver.Write).Table(tableName).Where("status = ?", statusFiring)
+		if req.HostIP != "" {
+			query = query.Where("host_ip = ?", req.HostIP)
+		}
+		if req.EventName != "" {
+			query = query.Where("event_name = ?", req.EventName)
+		}
+		if req.From != nil {
+			query = query.Where("timestamp >= ?", *req.From)
+		}
+		if req.To != nil {
+			query = query.Where("timestamp <= ?", *req.To)
+		}
+		return query
+	}
+
+	var acked int64
+	now := time.Now()
+	for {
+		var ids []uint64
+		if err := matching().Order("id").Limit(ackBatchSize).Pluck("id", &ids).Error; err != nil {
+			slog.Error("Failed to select alerts to acknowledge", "module", module, "error", err, "component", "monitor-web")
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to acknowledge alerts", "acked": acked})
+			return
+		}
+		if len(ids) == 0 {
+			break
+		}
+		result := db.Table(tableName).Where("id IN ? AND status = ?", ids, statusFiring).Updates(map[string]interface{}{
+			"status":   statusAcked,
+			"acked_by": req.Actor,
+			"acked_at": now,
+		})
+		if result.Error != nil {
+			slog.Error("Failed to acknowledge alerts", "module", module, "error", result.Error, "component", "monitor-web")
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to acknowledge alerts", "acked": acked})
+			return
+		}
+		acked += result.RowsAffected
+		if len(ids) < ackBatchSize {
+			break
+		}
+	}
+
+	slog.Info("Acknowledged alerts", "module", module, "acked", acked, "actor", req.Actor, "host_ip", req.HostIP, "event_name", req.EventName, "client_ip", c.ClientIP(), "component", "monitor-web")
+	respondJSON(c, http.StatusOK, gin.H{"module": module, "acked": acked})
+}
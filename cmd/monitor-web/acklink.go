@@ -0,0 +1,145 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// ackLinkActor is recorded as acked_by for alerts acknowledged through a signed link
+const ackLinkActor = "ack-link"
+
+// ackLinkTTL is how long a signed ack link stays valid
+var ackLinkTTL time.Duration
+
+var (
+	errInvalidAckToken = errors.New("invalid ack token")
+	errExpiredAckToken = errors.New("expired ack token")
+)
+
+// ackTokenMAC is the HMAC of the alert and expiry a token is scoped to, keyed by ACK_LINK_SECRET
+func ackTokenMAC(module string, id uint64, expires int64) []byte {
+	mac := hmac.New(sha256.New, []byte(viper.GetString("ACK_LINK_SECRET")))
+	fmt.Fprintf(mac, "%s:%d:%d", module, id, expires)
+	return mac.Sum(nil)
+}
+
+// signAckToken returns a token that acknowledges the module's alert id until expires,
+// formatted as <expiry unix seconds>.<base64url HMAC>
+func signAckToken(module string, id uint64, expires time.Time) string {
+	unix := expires.Unix()
+	return strconv.FormatInt(unix, 10) + "." + base64.RawURLEncoding.EncodeToString(ackTokenMAC(module, id, unix))
+}
+
+// verifyAckToken checks that token was signed for the module's alert id and has not expired
+func verifyAckToken(module string, id uint64, token string) error {
+	expiryPart, sigPart, ok := strings.Cut(token, ".")
+	if !ok {
+		return errInvalidAckToken
+	}
+	expires, err := strconv.ParseInt(expiryPart, 10, 64)
+	if err != nil {
+		return errInvalidAckToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(sigPart)
+	if err != nil || !hmac.Equal(sig, ackTokenMAC(module, id, expires)) {
+		return errInvalidAckToken
+	}
+	if time.Now().Unix() > expires {
+		return errExpiredAckToken
+	}
+	return nil
+}
+
+// ackLinkPath returns the signed one-click ack path for the module's alert id, valid for
+// ACK_LINK_TTL, or "" when ACK_LINK_SECRET is unset. The path includes API_BASE_PATH;
+// callers prefix their public base URL.
+func ackLinkPath(module string, id uint64) string {
+	if viper.GetString("ACK_LINK_SECRET") == "" {
+		return ""
+	}
+	token := signAckToken(module, id, time.Now().Add(ackLinkTTL))
+	return fmt.Sprintf("%s/api/alerts/%s/%d/ack?token=%s", apiBasePath, url.PathEscape(module), id, url.QueryEscape(token))
+}
+
+// ackAlertByLink godoc
+// @Summary Acknowledge an alert through a signed link
+// @Description One-click acknowledgement for links embedded in notifications: verifies the HMAC token, which is scoped to this alert and expires after ACK_LINK_TTL, then acknowledges the alert if it is still firing. Needs no other credentials; unavailable while ACK_LINK_SECRET is unset.
+// @Tags alerts
+// @Produce json
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param id path int true "Alert ID"
+// @Param token query string true "Signed ack token"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/{id}/ack [get]
+func ackAlertByLink(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+	if viper.GetString("ACK_LINK_SECRET") == "" {
+		respondJSON(c, http.StatusForbidden, gin.H{"error": "Ack links are disabled"})
+		return
+	}
+	if err := verifyAckToken(module, id, c.Query("token")); err != nil {
+		slog.Warn("Rejected ack link", "module", module, "id", id, "error", err, "client_ip", c.ClientIP(), "component", "monitor-web")
+		msg := "Invalid token"
+		if errors.Is(err, errExpiredAckToken) {
+			msg = "Expired token"
+		}
+		respondJSON(c, http.StatusForbidden, gin.H{"error": msg})
+		return
+	}
+
+	result := db.Table(tableName).Where("id = ? AND status = ?", id, statusFiring).Updates(map[string]interface{}{
+		"status":   statusAcked,
+		"acked_by": ackLinkActor,
+		"acked_at": time.Now(),
+	})
+	if result.Error != nil {
+		slog.Error("Failed to acknowledge alert", "module", module, "id", id, "error", result.Error, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to acknowledge alerts"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		// Already acknowledged or resolved, e.g. a second click: report the current state
+		var status string
+		if err := db.Table(tableName).Select("status").Where("id = ?", id).Scan(&status).Error; err != nil {
+			slog.Error("Failed to look up alert", "module", module, "id", id, "error", err, "component", "monitor-web")
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+			return
+		}
+		if status == "" {
+			respondJSON(c, http.StatusNotFound, gin.H{"error": "Alert not found"})
+			return
+		}
+		respondJSON(c, http.StatusOK, gin.H{"module": module, "id": id, "status": status})
+		return
+	}
+
+	slog.Info("Acknowledged alert via link", "module", module, "id", id, "client_ip", c.ClientIP(), "component", "monitor-web")
+	respondJSON(c, http.StatusOK, gin.H{"module": module, "id": id, "status": statusAcked})
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// moduleAliases maps alternative module names sent by agents to their canonical module
+var moduleAliases map[string]string
+
+// initModuleAliases parses MODULE_ALIASES, a comma-separated list of alias:module pairs
+// whose targets must be known modules
+func initModuleAliases() error {
+	moduleAliases = make(map[string]string)
+	for _, entry := range splitList(viper.GetString("MODULE_ALIASES")) {
+		alias, target, ok := strings.Cut(entry, ":")
+		alias, target = strings.TrimSpace(alias), strings.TrimSpace(target)
+		if !ok || alias == "" || target == "" {
+			return fmt.Errorf("invalid MONITOR_WEB_MODULE_ALIASES entry %q: want alias:module", entry)
+		}
+		if _, known := moduleTable(target); !known {
+			return fmt.Errorf("invalid MONITOR_WEB_MODULE_ALIASES entry %q: unknown module %q", entry, target)
+		}
+		moduleAliases[alias] = target
+	}
+	return nil
+}
+
+// canonicalModule returns the module that module is an alias for, or module itself
+func canonicalModule(module string) string {
+	if target, ok := moduleAliases[module]; ok {
+		return target
+	}
+	return module
+}
+
+// moduleParam returns the canonical module named by the :module path parameter
+func moduleParam(c *gin.Context) string {
+	param := c.Param("module")
+	module := canonicalModule(param)
+	if module != param {
+		slog.Debug("Applied module alias", "alias", param, "module", module, "component", "monitor-web")
+	}
+	return module
+}
@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestCanonicalModule(t *testing.T) {
+	loadTestConfig(t, map[string]string{"MODULE_ALIASES": "db-mysql:mysql, cache:redis"})
+	tests := map[string]string{
+		"db-mysql": "mysql",
+		"cache":    "redis",
+		"mysql":    "mysql",
+		"unknown":  "unknown",
+	}
+	for in, want := range tests {
+		if got := canonicalModule(in); got != want {
+			t.Errorf("canonicalModule(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestInitModuleAliasesRejectsBadEntries(t *testing.T) {
+	loadTestConfig(t, nil)
+	for _, aliases := range []string{"db-mysql", "db-mysql:", ":mysql", "db-pg:postgres"} {
+		t.Setenv("MONITOR_WEB_MODULE_ALIASES", aliases)
+		if err := initModuleAliases(); err == nil {
+			t.Errorf("initModuleAliases accepted MODULE_ALIASES=%q", aliases)
+		}
+	}
+}
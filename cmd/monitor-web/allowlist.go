@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// ingestAllowedNets are the parsed INGEST_ALLOWED_CIDRS; the check is disabled when empty
+var ingestAllowedNets []*net.IPNet
+
+// initIngestAllowList parses INGEST_ALLOWED_CIDRS, accepting bare IPs as single-host networks
+func initIngestAllowList() error {
+	ingestAllowedNets = nil
+	for _, entry := range splitList(viper.GetString("INGEST_ALLOWED_CIDRS")) {
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return fmt.Errorf("invalid MONITOR_WEB_INGEST_ALLOWED_CIDRS entry %q: not an IP or CIDR", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			ingestAllowedNets = append(ingestAllowedNets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return fmt.Errorf("invalid MONITOR_WEB_INGEST_ALLOWED_CIDRS entry %q: %w", entry, err)
+		}
+		ingestAllowedNets = append(ingestAllowedNets, ipNet)
+	}
+	return nil
+}
+
+// requireIngestSource rejects ingest requests whose client IP, as resolved through the
+// trusted proxies, is outside INGEST_ALLOWED_CIDRS
+func requireIngestSource(c *gin.Context) {
+	clientIP := c.ClientIP()
+	if ingestSourceAllowed(clientIP) {
+		c.Next()
+		return
+	}
+	slog.Warn("Rejected ingest from disallowed source", "client_ip", clientIP, "path", c.FullPath(), "component", "monitor-web")
+	respondJSON(c, http.StatusForbidden, gin.H{"error": "Source IP not allowed"})
+	c.Abort()
+}
+
+// ingestSourceAllowed reports whether clientIP may ingest under INGEST_ALLOWED_CIDRS
+func ingestSourceAllowed(clientIP string) bool {
+	if len(ingestAllowedNets) == 0 {
+		return true
+	}
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range ingestAllowedNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
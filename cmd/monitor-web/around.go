@@ -0,0 +1,76 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// getAlertsAround godoc
+// @Summary Get a host's alerts across modules around a moment
+// @Description For incident triage: merges the shared alert columns of every module table for the host within at ± window, oldest first, each tagged with its source module. At most MAX_PAGE_SIZE alerts are returned.
+// @Tags alerts
+// @Produce json
+// @Param host_ip query string true "Host IP (matches host_ip or any of host_ips)"
+// @Param at query string true "Moment of interest (RFC 3339)"
+// @Param window query string false "Half-width of the window around at (e.g., 10m, 1h)" default(10m)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/around [get]
+func getAlertsAround(c *gin.Context) {
+	hostIP := c.Query("host_ip")
+	if hostIP == "" {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Missing host_ip"})
+		return
+	}
+	at, err := time.Parse(time.RFC3339, c.Query("at"))
+	if err != nil {
+		slog.Warn("Invalid triage moment", "at", c.Query("at"), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid at"})
+		return
+	}
+	windowParam := c.DefaultQuery("window", "10m")
+	window, err := parseDuration(windowParam)
+	if err != nil || window <= 0 {
+		slog.Warn("Invalid triage window", "window", windowParam, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid window"})
+		return
+	}
+
+	limit := viper.GetInt("MAX_PAGE_SIZE")
+	columns := strings.Join(sharedColumnNames(), ", ")
+	var parts []string
+	var args []interface{}
+	for _, module := range storedModules() {
+		table, _ := moduleTable(module)
+		parts = append(parts, "(?)")
+		args = append(args, db.Table(table).
+			Select(columns+", ? AS source", module).
+			Where(matchHost(hostIP)).
+			Where("timestamp BETWEEN ? AND ?", at.Add(-window), at.Add(window)).
+			Order("timestamp, id").
+			Limit(limit))
+	}
+
+	alerts := []map[string]interface{}{}
+	sql := "SELECT * FROM (" + strings.Join(parts, " UNION ALL ") + ") AS around ORDER BY timestamp, source, id LIMIT ?"
+	if err := db.Raw(sql, append(args, limit)...).Scan(&alerts).Error; err != nil {
+		slog.Error("Failed to query alerts around moment", "host_ip", hostIP, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+	decodeJSONColumns(alerts, jsonColumns...)
+	utcTimestamps(alerts)
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"host":   hostIP,
+		"at":     at.UTC(),
+		"window": windowParam,
+		"alerts": alerts,
+	})
+}
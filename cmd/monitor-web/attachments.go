@@ -0,0 +1,163 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+var (
+	// attachmentDir is the ATTACHMENT_DIR holding attachment blobs ("" rejects blobs)
+	attachmentDir string
+	// attachmentTypes is the ATTACHMENT_TYPES allow-list of sniffed content types
+	attachmentTypes map[string]bool
+)
+
+var (
+	errAttachmentsDisabled = &ingestError{"Attachments are disabled"}
+	errAttachmentEncoding  = &ingestError{"Invalid attachment: must be base64"}
+	errAttachmentType      = &ingestError{"Unsupported attachment type"}
+	errAttachmentURL       = &ingestError{"Invalid attachment_url: must be an http or https URL"}
+)
+
+// initAttachments reads ATTACHMENT_DIR and ATTACHMENT_TYPES, creating the directory
+func initAttachments() error {
+	attachmentTypes = make(map[string]bool)
+	for _, t := range splitList(viper.GetString("ATTACHMENT_TYPES")) {
+		attachmentTypes[t] = true
+	}
+	if viper.GetInt("MAX_ATTACHMENT_BYTES") < 1 {
+		return fmt.Errorf("MONITOR_WEB_MAX_ATTACHMENT_BYTES must be positive")
+	}
+	attachmentDir = viper.GetString("ATTACHMENT_DIR")
+	if attachmentDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(attachmentDir, 0o700); err != nil {
+		return fmt.Errorf("invalid MONITOR_WEB_ATTACHMENT_DIR: %w", err)
+	}
+	return nil
+}
+
+// validateAttachmentURL rejects attachment_url values that are not absolute http(s) URLs
+func validateAttachmentURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errAttachmentURL
+	}
+	return nil
+}
+
+// storeAttachment decodes event's base64 attachment, checks its size and sniffed content
+// type, and writes it under ATTACHMENT_DIR. It returns the stored file name and content type,
+// or empty strings when the event has no attachment. The blob is dropped from the event and
+// served from /alerts/{module}/{id}/attachment once stored.
+func storeAttachment(event *AlertEvent) (file, contentType string, err error) {
+	if event.Attachment == "" {
+		return "", "", nil
+	}
+	if attachmentDir == "" {
+		return "", "", errAttachmentsDisabled
+	}
+	limit := viper.GetInt("MAX_ATTACHMENT_BYTES")
+	if base64.StdEncoding.DecodedLen(len(event.Attachment)) > limit+2 {
+		return "", "", &ingestError{fmt.Sprintf("Attachment too large: limit is %d bytes", limit)}
+	}
+	data, err := base64.StdEncoding.DecodeString(event.Attachment)
+	if err != nil {
+		return "", "", errAttachmentEncoding
+	}
+	if len(data) > limit {
+		return "", "", &ingestError{fmt.Sprintf("Attachment too large: limit is %d bytes", limit)}
+	}
+	contentType = http.DetectContentType(data)
+	if !attachmentTypes[contentType] {
+		return "", "", errAttachmentType
+	}
+
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	file = hex.EncodeToString(buf)
+	if err := os.WriteFile(filepath.Join(attachmentDir, file), data, 0o600); err != nil {
+		return "", "", fmt.Errorf("failed to write attachment: %w", err)
+	}
+	event.Attachment = ""
+	return file, contentType, nil
+}
+
+// discardAttachment removes the blob of a record that was not stored after all
+func discardAttachment(record alertRecord) {
+	file := record.base().AttachmentFile
+	if file == "" {
+		return
+	}
+	if err := os.Remove(filepath.Join(attachmentDir, file)); err != nil && !os.IsNotExist(err) {
+		slog.Warn("Failed to remove orphaned attachment", "file", file, "error", err, "component", "monitor-web")
+	}
+}
+
+// attachmentInfo is the stored attachment of one alert
+type attachmentInfo struct {
+	AttachmentFile string
+	AttachmentType string
+}
+
+// getAlertAttachment godoc
+// @Summary Get an alert's attachment
+// @Description Serves the attachment blob stored with the alert (e.g. a graph screenshot) with its content type. Alerts that only carry an attachment_url have no blob; the URL is returned with the alert itself.
+// @Tags alerts
+// @Produce octet-stream
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param id path int true "Alert ID"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/{id}/attachment [get]
+func getAlertAttachment(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	var info attachmentInfo
+	if err := db.Table(tableName).Select("attachment_file, attachment_type").Where("id = ?", id).Scan(&info).Error; err != nil {
+		slog.Error("Failed to look up attachment", "module", module, "id", id, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+	if info.AttachmentFile == "" || attachmentDir == "" {
+		respondJSON(c, http.StatusNotFound, gin.H{"error": "No attachment"})
+		return
+	}
+	path := filepath.Join(attachmentDir, filepath.Base(info.AttachmentFile))
+	if _, err := os.Stat(path); err != nil {
+		slog.Warn("Attachment file missing", "module", module, "id", id, "file", info.AttachmentFile, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusNotFound, gin.H{"error": "No attachment"})
+		return
+	}
+	c.Header("Content-Type", info.AttachmentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.File(path)
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// apiBasePath is the API_BASE_PATH every route is mounted under, e.g. "/monitor" for a
+// gateway serving /monitor/api/alerts; "" mounts the routes at the root
+var apiBasePath string
+
+// initBasePath reads API_BASE_PATH, dropping any trailing slash
+func initBasePath() error {
+	base := strings.TrimRight(viper.GetString("API_BASE_PATH"), "/")
+	if base != "" && (!strings.HasPrefix(base, "/") || strings.ContainsAny(base, ":*?#")) {
+		return fmt.Errorf("invalid MONITOR_WEB_API_BASE_PATH %q: want a path such as /monitor", viper.GetString("API_BASE_PATH"))
+	}
+	apiBasePath = base
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRoutesUnderBasePath(t *testing.T) {
+	loadTestConfig(t, map[string]string{"API_BASE_PATH": "/monitor/"})
+	mock := mockDB(t)
+	mock.ExpectQuery("FROM `redis_alerts`").WillReturnRows(emptyRows("id"))
+	mock.ExpectQuery("AS bucket").WillReturnRows(emptyRows("bucket", "severity", "count"))
+	r, err := newRouter()
+	if err != nil {
+		t.Fatalf("newRouter: %v", err)
+	}
+
+	w := serveRequest(r, httptest.NewRequest(http.MethodGet, "/monitor/api/alerts/redis", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("/monitor/api/alerts/redis status = %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Cache-Control"); got != "no-store" {
+		t.Errorf("Cache-Control = %q, want no-store", got)
+	}
+
+	if w := serveRequest(r, httptest.NewRequest(http.MethodGet, "/api/alerts/redis", nil)); w.Code != http.StatusNotFound {
+		t.Errorf("/api/alerts/redis status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestInitBasePathRejectsInvalid(t *testing.T) {
+	loadTestConfig(t, nil)
+	for _, base := range []string{"monitor", "/monitor/:id", "/mon*"} {
+		t.Setenv("MONITOR_WEB_API_BASE_PATH", base)
+		if err := initBasePath(); err == nil {
+			t.Errorf("initBasePath accepted API_BASE_PATH=%q", base)
+		}
+	}
+}
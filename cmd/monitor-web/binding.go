@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// maxBodySnippet bounds how much of a rejected request body is logged or returned
+const maxBodySnippet = 256
+
+// bindingErrorDetails describes why a request body failed to bind, including
+// the offending field where known and a snippet of the raw body around the problem
+func bindingErrorDetails(err error, body []byte) map[string]interface{} {
+	details := map[string]interface{}{"error": err.Error()}
+	offset := int64(-1)
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var validationErrs validator.ValidationErrors
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+		details["field"] = typeErr.Field
+		details["expected_type"] = typeErr.Type.String()
+		details["got"] = typeErr.Value
+	case errors.As(err, &validationErrs):
+		fields := make([]map[string]string, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, map[string]string{"field": fe.Field(), "rule": fe.Tag()})
+		}
+		details["fields"] = fields
+	}
+	if offset >= 0 {
+		details["offset"] = offset
+	}
+	details["body_snippet"] = bodySnippet(body, offset)
+	return details
+}
+
+// bodySnippet returns up to maxBodySnippet bytes of body, centred on offset when it is known
+func bodySnippet(body []byte, offset int64) string {
+	start := 0
+	if offset > maxBodySnippet/2 {
+		start = int(offset) - maxBodySnippet/2
+	}
+	if start > len(body) {
+		start = len(body)
+	}
+	end := start + maxBodySnippet
+	if end > len(body) {
+		end = len(body)
+	}
+	return strings.ToValidUTF8(string(body[start:end]), "")
+}
+
+// unknownFields returns the dotted paths of keys in the JSON object body that t, a
+// struct type, does not declare, descending into nested struct fields. Keys match
+// case-insensitively, as encoding/json binds them.
+func unknownFields(body []byte, t reflect.Type) []string {
+	var unknown []string
+	collectUnknownFields(body, t, "", &unknown)
+	sort.Strings(unknown)
+	return unknown
+}
+
+func collectUnknownFields(body []byte, t reflect.Type, prefix string, unknown *[]string) {
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(body, &obj) != nil {
+		return
+	}
+	fields := jsonFields(t)
+	for key, raw := range obj {
+		ft, ok := lookupJSONField(fields, key)
+		if !ok {
+			*unknown = append(*unknown, prefix+key)
+			continue
+		}
+		if ft.Kind() == reflect.Struct && ft != reflect.TypeOf(time.Time{}) {
+			collectUnknownFields(raw, ft, prefix+key+".", unknown)
+		}
+	}
+}
+
+// lookupJSONField finds key in fields the way encoding/json does: an exact match first,
+// then a case-insensitive one
+func lookupJSONField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if ft, ok := fields[key]; ok {
+		return ft, true
+	}
+	for name, ft := range fields {
+		if strings.EqualFold(name, key) {
+			return ft, true
+		}
+	}
+	return nil, false
+}
+
+// jsonFields maps the JSON names of t's fields, including promoted ones, to their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && name == "" {
+			for k, v := range jsonFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
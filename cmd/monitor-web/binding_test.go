@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnknownFields(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		t    reflect.Type
+		want []string
+	}{
+		{
+			name: "valid v1 payload",
+			body: `{"timestamp":"2024-05-01T12:00:00Z","module":"host","service_name":"node","event_name":"cpu_high","cpu_usage":91.5,"labels":{"team":"ops"}}`,
+			t:    reflect.TypeOf(AlertEvent{}),
+		},
+		{
+			name: "misspelled v1 field",
+			body: `{"module":"host","service_name":"node","event_name":"cpu_high","cpu_useage":91.5}`,
+			t:    reflect.TypeOf(AlertEvent{}),
+			want: []string{"cpu_useage"},
+		},
+		{
+			name: "differently cased v1 fields",
+			body: `{"Module":"host","SERVICE_NAME":"node","Event_Name":"cpu_high","CPU_Useage":91.5}`,
+			t:    reflect.TypeOf(AlertEvent{}),
+			want: []string{"CPU_Useage"},
+		},
+		{
+			name: "differently cased v2 fields",
+			body: `{"Common":{"Module":"host","Foo":1},"Module_Data":{"CPU_USAGE":91.5}}`,
+			t:    reflect.TypeOf(AlertEventV2{}),
+			want: []string{"Common.Foo"},
+		},
+		{
+			name: "valid v2 payload",
+			body: `{"common":{"module":"host","event_name":"cpu_high"},"module_data":{"cpu_usage":91.5}}`,
+			t:    reflect.TypeOf(AlertEventV2{}),
+		},
+		{
+			name: "unknown nested v2 field",
+			body: `{"common":{"module":"host","foo":1},"module_data":{"cpu_usage":91.5},"extra":true}`,
+			t:    reflect.TypeOf(AlertEventV2{}),
+			want: []string{"common.foo", "extra"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unknownFields([]byte(tt.body), tt.t); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unknownFields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxBodyDedupEntries bounds the remembered requests; new ones are not remembered while it is full
+	maxBodyDedupEntries = 10000
+	// maxBodyDedupBytes is the largest body hashed; bigger uploads are never deduplicated
+	maxBodyDedupBytes = 1 << 20
+)
+
+// bodyDedupWindow is how long BODY_DEDUP_WINDOW remembers an ingest request (0 disables it)
+var bodyDedupWindow time.Duration
+
+// bodyDedupEntry is the outcome of the first request with a given body hash; done is closed
+// once status and resp are set
+type bodyDedupEntry struct {
+	done       chan struct{}
+	status     int
+	resp       []byte
+	receivedAt time.Time
+}
+
+var (
+	bodyDedupMu      sync.Mutex
+	bodyDedupEntries = make(map[string]*bodyDedupEntry)
+)
+
+// bodyCapture records what a handler writes so the response can be referenced later
+type bodyCapture struct {
+	gin.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (w *bodyCapture) Write(b []byte) (int, error) {
+	w.buf.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyCapture) WriteString(s string) (int, error) {
+	w.buf.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// dedupRequestBody answers a byte-identical repeat of an ingest request seen within
+// BODY_DEDUP_WINDOW, such as a proxy retry, with 200 and the first request's response
+// instead of ingesting it again. Requests are identical when their path, query, payload
+// headers and raw body match; only successful responses are remembered, so a request that
+// failed can be retried. A repeat arriving while the first is still in flight waits for it.
+func dedupRequestBody(c *gin.Context) {
+	if bodyDedupWindow <= 0 {
+		c.Next()
+		return
+	}
+	raw, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBodyDedupBytes+1))
+	if err != nil {
+		slog.Warn("Failed to read request body for dedup", "error", err, "client_ip", c.ClientIP(), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		c.Abort()
+		return
+	}
+	if len(raw) > maxBodyDedupBytes {
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(raw), c.Request.Body))
+		c.Next()
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(raw))
+
+	key := requestBodyHash(c, raw)
+	var entry *bodyDedupEntry
+	for {
+		var first bool
+		if entry, first = claimRequestBody(key); first {
+			break
+		}
+		select {
+		case <-entry.done:
+		case <-c.Request.Context().Done():
+			c.Abort()
+			return
+		}
+		if entry.status == 0 {
+			// The first request failed and was forgotten; this one may take its place
+			continue
+		}
+		slog.Info("Answered duplicate request body", "path", c.Request.URL.Path, "received_at", entry.receivedAt.UTC(), "client_ip", c.ClientIP(), "component", "monitor-web")
+		resp := gin.H{"status": "duplicate_request", "receivedAt": entry.receivedAt.UTC(), "originalStatus": entry.status}
+		if json.Valid(entry.resp) {
+			resp["original"] = json.RawMessage(entry.resp)
+		}
+		respondJSON(c, http.StatusOK, resp)
+		c.Abort()
+		return
+	}
+
+	capture := &bodyCapture{ResponseWriter: c.Writer}
+	c.Writer = capture
+	status := 0
+	defer func() {
+		settleRequestBody(key, entry, status, capture.buf.Bytes())
+	}()
+	c.Next()
+	if s := c.Writer.Status(); s >= 200 && s < 300 {
+		status = s
+	}
+}
+
+// requestBodyHash identifies a request by everything that decides how its body is ingested
+func requestBodyHash(c *gin.Context, raw []byte) string {
+	h := sha256.New()
+	for _, part := range []string{
+		c.Request.Method,
+		c.Request.URL.Path,
+		c.Request.URL.RawQuery,
+		c.ContentType(),
+		c.GetHeader("Content-Encoding"),
+		c.GetHeader(schemaVersionHeader),
+		c.GetHeader(ingestKeyHeader),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(raw)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// claimRequestBody returns the live entry for key, or records a pending one and reports
+// that this request is the first
+func claimRequestBody(key string) (*bodyDedupEntry, bool) {
+	now := time.Now()
+	bodyDedupMu.Lock()
+	defer bodyDedupMu.Unlock()
+	if entry, ok := bodyDedupEntries[key]; ok && now.Sub(entry.receivedAt) <= bodyDedupWindow {
+		return entry, false
+	}
+	if len(bodyDedupEntries) >= maxBodyDedupEntries {
+		for k, entry := range bodyDedupEntries {
+			if now.Sub(entry.receivedAt) > bodyDedupWindow {
+				delete(bodyDedupEntries, k)
+			}
+		}
+	}
+	entry := &bodyDedupEntry{done: make(chan struct{}), receivedAt: now}
+	if len(bodyDedupEntries) < maxBodyDedupEntries {
+		bodyDedupEntries[key] = entry
+	}
+	return entry, true
+}
+
+// settleRequestBody records the outcome of entry, the first request with key, and releases
+// waiting repeats; a status of 0 (the request did not succeed) forgets the request
+func settleRequestBody(key string, entry *bodyDedupEntry, status int, resp []byte) {
+	bodyDedupMu.Lock()
+	defer bodyDedupMu.Unlock()
+	if status == 0 {
+		if bodyDedupEntries[key] == entry {
+			delete(bodyDedupEntries, key)
+		}
+	} else {
+		entry.status = status
+		entry.resp = append([]byte(nil), resp...)
+	}
+	close(entry.done)
+}
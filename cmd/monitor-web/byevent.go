@@ -0,0 +1,70 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// eventCount is the number of alerts recorded for one event_name
+type eventCount struct {
+	EventName string `json:"event_name"`
+	Count     int64  `json:"count"`
+}
+
+// getAlertsByEvent godoc
+// @Summary Get alert counts by event name for a module
+// @Description Returns the number of alerts per event_name, most frequent first, honoring the same filters as the alerts listing. With READ_CACHE_TTL set, a failed query is answered with the last successful response for the same URL, flagged stale: true. With FILTER_CACHE_TTL set, responses are cached for that long or until an alert of the module is stored or resolved.
+// @Tags alerts
+// @Produce json
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param alert_type query string false "Alert type filter"
+// @Param q query string false "Search text in details (substring match, or full-text match with ENABLE_FULLTEXT)"
+// @Param host_ip query string false "Host IP filter (matches host_ip or any of host_ips)"
+// @Param label.key query string false "Label filter, e.g. label.team=payments"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/by-event [get]
+func getAlertsByEvent(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+	if cachedFilterRead(c) {
+		return
+	}
+
+	query := db.Table(tableName).
+		Select("event_name, COUNT(*) AS count").
+		Group("event_name").
+		Order("count DESC")
+	query, appliedRange := applyAlertFilters(c, query)
+
+	counts := []eventCount{}
+	if err := query.Scan(&counts).Error; err != nil {
+		slog.Error("Failed to count alerts by event", "module", module, "error", err, "component", "monitor-web")
+		if serveStaleRead(c) {
+			return
+		}
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+
+	resp := gin.H{
+		"module": module,
+		"events": counts,
+	}
+	if appliedRange != nil {
+		resp["defaultRange"] = appliedRange
+	}
+	cacheRead(c, resp)
+	cacheFilterRead(c, module, resp)
+	respondJSON(c, http.StatusOK, resp)
+}
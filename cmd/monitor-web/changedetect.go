@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/spf13/viper"
+	"gorm.io/plugin/dbresolver"
+)
+
+var (
+	// changeDetectModules are the CHANGE_DETECT_MODULES whose alerts are only stored when a
+	// numeric field moved by more than metricEpsilon since the last open alert
+	changeDetectModules map[string]bool
+	// metricEpsilon is HOST_METRIC_EPSILON, the largest change still treated as no change
+	metricEpsilon float64
+)
+
+// initChangeDetection parses CHANGE_DETECT_MODULES and HOST_METRIC_EPSILON
+func initChangeDetection() error {
+	changeDetectModules = make(map[string]bool)
+	for _, module := range splitList(viper.GetString("CHANGE_DETECT_MODULES")) {
+		if _, ok := moduleModels[module]; !ok {
+			return fmt.Errorf("invalid MONITOR_WEB_CHANGE_DETECT_MODULES entry %q: not a module with metric fields", module)
+		}
+		changeDetectModules[module] = true
+	}
+	epsilon, err := strconv.ParseFloat(viper.GetString("HOST_METRIC_EPSILON"), 64)
+	if err != nil || epsilon < 0 || math.IsNaN(epsilon) {
+		return fmt.Errorf("invalid MONITOR_WEB_HOST_METRIC_EPSILON %q: want a non-negative number", viper.GetString("HOST_METRIC_EPSILON"))
+	}
+	metricEpsilon = epsilon
+	return nil
+}
+
+// unchangedAlertID returns the id of the most recent open alert sharing record's fingerprint
+// when none of record's numeric module fields differ from it by more than HOST_METRIC_EPSILON,
+// or 0 when record should be stored. Modules not in CHANGE_DETECT_MODULES always store.
+// Ingest answers a skipped event with status unchanged and that id.
+func unchangedAlertID(record alertRecord) (uint64, error) {
+	if !changeDetectModules[record.base().Module] {
+		return 0, nil
+	}
+	last := reflect.New(reflect.TypeOf(record).Elem())
+	// Read from the primary so a burst of reports compares against the latest one stored
+	result := whereFingerprint(db.Clauses(dbresolver.Write).Model(record), record.base()).
+		Where("status <> ?", statusResolved).
+		Order("timestamp desc, id desc").
+		Limit(1).
+		Find(last.Interface())
+	if result.Error != nil || result.RowsAffected == 0 {
+		return 0, result.Error
+	}
+	if metricsChanged(reflect.ValueOf(record).Elem(), last.Elem()) {
+		return 0, nil
+	}
+	return last.Interface().(alertRecord).base().ID, nil
+}
+
+// metricsChanged reports whether any numeric module field of a and b, values of the same
+// model, differs by more than HOST_METRIC_EPSILON. The embedded Alert is not compared.
+func metricsChanged(a, b reflect.Value) bool {
+	for i := 0; i < a.NumField(); i++ {
+		if a.Type().Field(i).Anonymous {
+			continue
+		}
+		x, ok := numericValue(a.Field(i))
+		if !ok {
+			continue
+		}
+		y, _ := numericValue(b.Field(i))
+		if math.Abs(x-y) > metricEpsilon {
+			return true
+		}
+	}
+	return false
+}
+
+// numericValue returns v as a float64 when it holds a number
+func numericValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// chartSpecVersion is bumped whenever the chart spec layout changes incompatibly
+const chartSpecVersion = 1
+
+// chartColor is the color of the alert count dataset, and of severities without their own
+const chartColor = "#3b82f6"
+
+// unspecifiedSeverity labels the dataset of alerts without an alert_type when others have one
+const unspecifiedSeverity = "unspecified"
+
+// severityColors are the SEVERITY_COLORS chart colors, keyed by alert_type
+var severityColors map[string]string
+
+// initSeverityColors parses SEVERITY_COLORS, a comma-separated list of severity:color pairs
+func initSeverityColors() error {
+	severityColors = make(map[string]string)
+	for _, entry := range splitList(viper.GetString("SEVERITY_COLORS")) {
+		severity, color, ok := strings.Cut(entry, ":")
+		severity, color = strings.TrimSpace(severity), strings.TrimSpace(color)
+		if !ok || severity == "" || color == "" {
+			return fmt.Errorf("invalid MONITOR_WEB_SEVERITY_COLORS entry %q: want severity:color", entry)
+		}
+		severityColors[severity] = color
+	}
+	return nil
+}
+
+// utcTimestampSQL is the timestamp column converted to UTC. The DSN's loc=Local stores
+// DATETIMEs in local time, the session time zone when the app and database share a zone,
+// so bucketing on the raw column would shift labels against the UTC timestamps the API returns.
+const utcTimestampSQL = "CONVERT_TZ(timestamp, @@session.time_zone, '+00:00')"
+
+// chartBuckets maps each chart granularity to the SQL expression that truncates
+// timestamp to its UTC bucket label; weeks are labelled by their Monday
+var chartBuckets = map[string]string{
+	"hour": "DATE_FORMAT(" + utcTimestampSQL + ", '%Y-%m-%d %H:00')",
+	"day":  "DATE_FORMAT(" + utcTimestampSQL + ", '%Y-%m-%d')",
+	"week": "DATE_FORMAT(DATE_SUB(" + utcTimestampSQL + ", INTERVAL WEEKDAY(" + utcTimestampSQL + ") DAY), '%Y-%m-%d')",
+}
+
+// bucketCount is the number of alerts of one severity in one chart bucket
+type bucketCount struct {
+	Bucket   string
+	Severity string
+	Count    int
+}
+
+// queryChartBuckets counts the alerts in tableName per bucket and severity (alert_type),
+// honoring the request's filters, and returns the default range applied as applyAlertFilters does
+func queryChartBuckets(c *gin.Context, tableName, bucketExpr string) ([]bucketCount, gin.H, error) {
+	var buckets []bucketCount
+	query := db.Table(tableName).
+		Select(bucketExpr + " AS bucket, alert_type AS severity, COUNT(*) AS count").
+		Group("bucket, severity").
+		Order("bucket")
+	query, appliedRange := applyAlertFilters(c, query)
+	err := query.Scan(&buckets).Error
+	return buckets, appliedRange, err
+}
+
+// buildChartSpec returns the complete Chart.js spec for the bucketed alert counts, so
+// every client renders the same chart without assembling its own config. Each severity gets
+// its own dataset in its SEVERITY_COLORS color; without severities there is a single dataset.
+func buildChartSpec(buckets []bucketCount, bucket string) map[string]interface{} {
+	labels, severities := []string{}, []string{}
+	counts := make(map[string]map[string]int)
+	seen := make(map[string]bool)
+	for _, b := range buckets {
+		if !seen[b.Bucket] {
+			seen[b.Bucket] = true
+			labels = append(labels, b.Bucket)
+		}
+		if counts[b.Severity] == nil {
+			counts[b.Severity] = make(map[string]int)
+			severities = append(severities, b.Severity)
+		}
+		counts[b.Severity][b.Bucket] += b.Count
+	}
+	sort.Strings(labels)
+	sort.Strings(severities)
+
+	// Alerts without any alert_type keep the original single blue dataset
+	if len(severities) <= 1 && (len(severities) == 0 || severities[0] == "") {
+		data := make([]int, len(labels))
+		for i, label := range labels {
+			data[i] = counts[""][label]
+		}
+		return chartSpec(bucket, labels, chartDataset("Alert Count", chartColor, data))
+	}
+
+	datasets := make([]map[string]interface{}, 0, len(severities))
+	for _, severity := range severities {
+		data := make([]int, len(labels))
+		for i, label := range labels {
+			data[i] = counts[severity][label]
+		}
+		name, color := severity, chartColor
+		if name == "" {
+			name = unspecifiedSeverity
+		}
+		if c, ok := severityColors[severity]; ok {
+			color = c
+		}
+		datasets = append(datasets, chartDataset(name, color, data))
+	}
+	return chartSpec(bucket, labels, datasets...)
+}
+
+// chartDataset is one line of the chart
+func chartDataset(label, color string, data []int) map[string]interface{} {
+	return map[string]interface{}{
+		"label":           label,
+		"data":            data,
+		"borderColor":     color,
+		"backgroundColor": color,
+		"fill":            false,
+	}
+}
+
+// chartSpec assembles the versioned spec around labels and datasets
+func chartSpec(bucket string, labels []string, datasets ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"version":  chartSpecVersion,
+		"type":     "line",
+		"labels":   labels,
+		"datasets": datasets,
+		"options": map[string]interface{}{
+			"scales": map[string]interface{}{
+				"x": map[string]interface{}{
+					"title": map[string]interface{}{"display": true, "text": bucket},
+				},
+				"y": map[string]interface{}{
+					"beginAtZero": true,
+					"ticks":       map[string]interface{}{"precision": 0},
+					"title":       map[string]interface{}{"display": true, "text": "Alerts"},
+				},
+			},
+		},
+	}
+}
+
+// getAlertChart godoc
+// @Summary Get the chart spec for a module
+// @Description Returns the versioned Chart.js spec (labels, datasets, colors and axis config) of alert counts per bucket, the same spec embedded as chartData in the alerts listing. With READ_CACHE_TTL set, a failed query is answered with the last successful response for the same URL, flagged stale: true.
+// @Tags alerts
+// @Produce json
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param from query string false "Start date (YYYY-MM-DD); defaults to now minus DEFAULT_RANGE when from and to are omitted"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param alert_type query string false "Alert type filter"
+// @Param q query string false "Search text in details (substring match, or full-text match with ENABLE_FULLTEXT)"
+// @Param bucket query string false "Chart granularity: hour, day or week (weeks start on Monday)" default(day)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/chart [get]
+func getAlertChart(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+
+	bucket := c.DefaultQuery("bucket", "day")
+	bucketExpr, ok := chartBuckets[bucket]
+	if !ok {
+		slog.Warn("Invalid chart bucket", "bucket", bucket, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid bucket"})
+		return
+	}
+
+	buckets, appliedRange, err := queryChartBuckets(c, tableName, bucketExpr)
+	if err != nil {
+		slog.Error("Failed to aggregate alerts", "module", module, "bucket", bucket, "error", err, "component", "monitor-web")
+		if serveStaleRead(c) {
+			return
+		}
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+
+	resp := gin.H{
+		"module": module,
+		"bucket": bucket,
+		"chart":  buildChartSpec(buckets, bucket),
+	}
+	if appliedRange != nil {
+		resp["defaultRange"] = appliedRange
+	}
+	cacheRead(c, resp)
+	respondJSON(c, http.StatusOK, resp)
+}
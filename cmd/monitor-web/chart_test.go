@@ -0,0 +1,84 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// specDatasets returns the datasets of a chart spec by label
+func specDatasets(t *testing.T, spec map[string]interface{}) map[string]map[string]interface{} {
+	t.Helper()
+	datasets := make(map[string]map[string]interface{})
+	for _, ds := range spec["datasets"].([]map[string]interface{}) {
+		datasets[ds["label"].(string)] = ds
+	}
+	return datasets
+}
+
+func TestBuildChartSpecShape(t *testing.T) {
+	for bucket, labels := range map[string][]string{
+		"hour": {"2024-05-01 10:00", "2024-05-01 11:00"},
+		"day":  {"2024-05-01", "2024-05-02"},
+		"week": {"2024-04-29", "2024-05-06"},
+	} {
+		t.Run(bucket, func(t *testing.T) {
+			// Buckets arrive unsorted; the spec orders its labels
+			spec := buildChartSpec([]bucketCount{{Bucket: labels[1], Count: 2}, {Bucket: labels[0], Count: 5}}, bucket)
+			if spec["version"] != chartSpecVersion || spec["type"] != "line" {
+				t.Errorf("version/type = %v/%v", spec["version"], spec["type"])
+			}
+			if !reflect.DeepEqual(spec["labels"], labels) {
+				t.Errorf("labels = %v, want %v", spec["labels"], labels)
+			}
+			x := spec["options"].(map[string]interface{})["scales"].(map[string]interface{})["x"].(map[string]interface{})
+			if title := x["title"].(map[string]interface{})["text"]; title != bucket {
+				t.Errorf("x axis title = %v, want %s", title, bucket)
+			}
+			datasets := specDatasets(t, spec)
+			count, ok := datasets["Alert Count"]
+			if len(datasets) != 1 || !ok {
+				t.Fatalf("datasets = %v, want the single Alert Count dataset", datasets)
+			}
+			if !reflect.DeepEqual(count["data"], []int{5, 2}) || count["borderColor"] != chartColor {
+				t.Errorf("Alert Count = %v, want data [5 2] in %s", count, chartColor)
+			}
+		})
+	}
+}
+
+func TestBuildChartSpecSeverityDatasets(t *testing.T) {
+	loadTestConfig(t, map[string]string{"SEVERITY_COLORS": "critical:#dc2626"})
+	spec := buildChartSpec([]bucketCount{
+		{Bucket: "2024-05-01", Severity: "critical", Count: 3},
+		{Bucket: "2024-05-02", Severity: "warning", Count: 4},
+		{Bucket: "2024-05-02", Severity: "", Count: 1},
+	}, "day")
+
+	datasets := specDatasets(t, spec)
+	tests := map[string]struct {
+		data  []int
+		color string
+	}{
+		"critical":          {[]int{3, 0}, "#dc2626"},
+		"warning":           {[]int{0, 4}, chartColor},
+		unspecifiedSeverity: {[]int{0, 1}, chartColor},
+	}
+	if len(datasets) != len(tests) {
+		t.Fatalf("datasets = %v, want one per severity", datasets)
+	}
+	for severity, want := range tests {
+		ds := datasets[severity]
+		if !reflect.DeepEqual(ds["data"], want.data) || ds["borderColor"] != want.color {
+			t.Errorf("%s dataset = %v, want data %v in %s", severity, ds, want.data, want.color)
+		}
+	}
+}
+
+func TestChartBucketsUseUTC(t *testing.T) {
+	for bucket, expr := range chartBuckets {
+		if !strings.Contains(expr, utcTimestampSQL) {
+			t.Errorf("%s bucket %q does not convert timestamp to UTC", bucket, expr)
+		}
+	}
+}
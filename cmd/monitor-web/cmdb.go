@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// cmdbFetchTimeout bounds fetching CMDB_SOURCE when it is a URL
+const cmdbFetchTimeout = 10 * time.Second
+
+// cmdbHost is what the CMDB records about one host
+type cmdbHost struct {
+	Environment string `json:"environment"`
+	Role        string `json:"role"`
+	Owner       string `json:"owner"`
+}
+
+// cmdbHosts is the host_ip to CMDB entry mapping loaded from CMDB_SOURCE, replaced whole
+// by each load like serviceOwners
+var cmdbHosts atomic.Pointer[map[string]cmdbHost]
+
+// loadCMDB reads CMDB_SOURCE, a file path or http(s) URL serving a JSON object mapping host
+// IPs to {environment, role, owner}. Without CMDB_SOURCE every alert gets empty values.
+func loadCMDB() error {
+	hosts := map[string]cmdbHost{}
+	if source := viper.GetString("CMDB_SOURCE"); source != "" {
+		raw, err := readCMDBSource(source)
+		if err != nil {
+			return fmt.Errorf("failed to read MONITOR_WEB_CMDB_SOURCE: %w", err)
+		}
+		if err := json.Unmarshal(raw, &hosts); err != nil {
+			return fmt.Errorf("invalid MONITOR_WEB_CMDB_SOURCE %s: %w", source, err)
+		}
+	}
+	cmdbHosts.Store(&hosts)
+	return nil
+}
+
+// readCMDBSource returns the contents of the CMDB file or URL
+func readCMDBSource(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.ReadFile(source)
+	}
+	client := &http.Client{Timeout: cmdbFetchTimeout}
+	resp, err := client.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", source, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// cmdbLookup returns the CMDB entry of hostIP, or the zero entry when the CMDB does not list it
+func cmdbLookup(hostIP string) cmdbHost {
+	if hosts := cmdbHosts.Load(); hosts != nil {
+		return (*hosts)[hostIP]
+	}
+	return cmdbHost{}
+}
+
+// enrichFromCMDB sets alert's environment, role and host owner from its host's CMDB entry
+func enrichFromCMDB(alert *Alert) {
+	host := cmdbLookup(alert.HostIP)
+	alert.Environment = host.Environment
+	alert.Role = host.Role
+	alert.HostOwner = host.Owner
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxColumnarRows bounds the rows of one columnar batch
+const maxColumnarRows = 10000
+
+// columnarBatch is a column-oriented batch of alerts of one module: entry i of every array
+// describes row i. Arrays that are omitted leave the field unset on every row; module
+// fields accept null entries for rows that do not report them. The column tag names the
+// AlertEvent field each array fills.
+type columnarBatch struct {
+	Module       string              `json:"module"`
+	Count        int                 `json:"count"`
+	Timestamps   []time.Time         `json:"timestamps" column:"Timestamp"`
+	ServiceNames []string            `json:"service_names" column:"ServiceName"`
+	EventNames   []string            `json:"event_names" column:"EventName"`
+	Details      []string            `json:"details" column:"Details"`
+	HostIPs      []string            `json:"host_ips" column:"HostIP"` // One host_ip per row
+	AlertTypes   []string            `json:"alert_types" column:"AlertType"`
+	ClusterNames []string            `json:"cluster_names" column:"ClusterName"`
+	Hostnames    []string            `json:"hostnames" column:"Hostname"`
+	States       []string            `json:"states" column:"State"`
+	Labels       []map[string]string `json:"labels" column:"Labels"`
+
+	BigKeysCounts         []*int     `json:"big_keys_counts" column:"BigKeysCount"`
+	FailedNodes           []*string  `json:"failed_nodes" column:"FailedNodes"`
+	DeadlocksIncrements   []*int64   `json:"deadlocks_increments" column:"DeadlocksInc"`
+	SlowQueriesIncrements []*int64   `json:"slow_queries_increments" column:"SlowQueriesInc"`
+	Connections           []*int     `json:"connections" column:"Connections"`
+	CPUUsages             []*float64 `json:"cpu_usages" column:"CPUUsage"`
+	MemRemainings         []*float64 `json:"mem_remainings" column:"MemRemaining"`
+	DiskUsages            []*float64 `json:"disk_usages" column:"DiskUsage"`
+	AddedUsers            []*string  `json:"added_users" column:"AddedUsers"`
+	RemovedUsers          []*string  `json:"removed_users" column:"RemovedUsers"`
+	AddedProcesses        []*string  `json:"added_processes" column:"AddedProcesses"`
+	RemovedProcesses      []*string  `json:"removed_processes" column:"RemovedProcesses"`
+}
+
+// checkLengths reports the first array whose length differs from Count
+func (b *columnarBatch) checkLengths() error {
+	v := reflect.ValueOf(b).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("column") == "" || v.Field(i).IsNil() {
+			continue
+		}
+		if n := v.Field(i).Len(); n != b.Count {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			return fmt.Errorf("%s has %d entries, want count %d", name, n, b.Count)
+		}
+	}
+	return nil
+}
+
+// events expands the batch into one AlertEvent per row
+func (b *columnarBatch) events() []AlertEvent {
+	events := make([]AlertEvent, b.Count)
+	v := reflect.ValueOf(b).Elem()
+	t := v.Type()
+	for i := range events {
+		events[i].Module = b.Module
+		ev := reflect.ValueOf(&events[i]).Elem()
+		for j := 0; j < t.NumField(); j++ {
+			field := t.Field(j).Tag.Get("column")
+			if field == "" || v.Field(j).IsNil() {
+				continue
+			}
+			ev.FieldByName(field).Set(v.Field(j).Index(i))
+		}
+	}
+	return events
+}
+
+// receiveColumnarAlerts godoc
+// @Summary Ingest a column-oriented batch of alerts
+// @Description Bandwidth-efficient bulk ingest for high-volume agents: a single module's alerts sent as parallel arrays (timestamps, event_names, host_ips with one host_ip per row, ...), where entry i of every array describes row i and every array given must have exactly count entries. Rows are expanded server-side, validated like POST /alerts and batch-inserted like POST /alerts/import, whose summary is returned; a failed row is reported with its 1-based row number as line. Rows with state resolved resolve the matching open alert. Fan-out and attachments are not supported. At most 10000 rows per batch.
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param batch body columnarBatch true "Columnar alert batch"
+// @Param X-Ingest-Key header string false "Shared secret for the batch's module, required when INGEST_KEY or INGEST_KEYS covers it"
+// @Success 200 {object} importSummary
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /alerts/columnar [post]
+func receiveColumnarAlerts(c *gin.Context) {
+	var batch columnarBatch
+	if !bindAlertBody(c, &batch) {
+		return
+	}
+	if batch.Count <= 0 || batch.Count > maxColumnarRows {
+		slog.Warn("Invalid columnar batch count", "count", batch.Count, "client_ip", c.ClientIP(), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid count: want 1 to %d rows", maxColumnarRows)})
+		return
+	}
+	if err := batch.checkLengths(); err != nil {
+		slog.Warn("Rejected columnar batch with mismatched columns", "module", batch.Module, "error", err, "client_ip", c.ClientIP(), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Mismatched columns: " + err.Error()})
+		return
+	}
+
+	summary := &importSummary{Lines: batch.Count, Failures: []importFailure{}}
+	rows := make([]importRow, 0, importBatchSize)
+	events := batch.events()
+	for i := range events {
+		record := stageImportEvent(c, &events[i], i+1, summary)
+		if record == nil {
+			continue
+		}
+		rows = append(rows, importRow{line: i + 1, record: record})
+		if len(rows) == importBatchSize {
+			flushImportBatch(rows, summary)
+			rows = rows[:0]
+		}
+	}
+	flushImportBatch(rows, summary)
+
+	slog.Info("Received columnar alerts", "module", batch.Module, "rows", summary.Lines, "stored", summary.Stored, "resolved", summary.Resolved, "failed", summary.Failed, "client_ip", c.ClientIP(), "component", "monitor-web")
+	respondJSON(c, http.StatusOK, summary)
+}
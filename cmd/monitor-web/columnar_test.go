@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestColumnarBatchExpandsRows(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cpu := 91.5
+	batch := columnarBatch{
+		Module:       "host",
+		Count:        2,
+		Timestamps:   []time.Time{ts, ts.Add(time.Minute)},
+		ServiceNames: []string{"node", "node"},
+		EventNames:   []string{"cpu_high", "cpu_high"},
+		HostIPs:      []string{"10.0.0.1", "10.0.0.2"},
+		CPUUsages:    []*float64{&cpu, nil},
+	}
+	if err := batch.checkLengths(); err != nil {
+		t.Fatalf("checkLengths: %v", err)
+	}
+
+	events := batch.events()
+	if len(events) != batch.Count {
+		t.Fatalf("got %d events, want %d", len(events), batch.Count)
+	}
+	for i, event := range events {
+		if event.Module != "host" || event.ServiceName != "node" || event.EventName != "cpu_high" {
+			t.Errorf("row %d: got %s/%s/%s", i, event.Module, event.ServiceName, event.EventName)
+		}
+		if event.HostIP != batch.HostIPs[i] {
+			t.Errorf("row %d: host_ip = %q, want %q", i, event.HostIP, batch.HostIPs[i])
+		}
+		if !event.Timestamp.Equal(batch.Timestamps[i]) {
+			t.Errorf("row %d: timestamp = %v, want %v", i, event.Timestamp, batch.Timestamps[i])
+		}
+	}
+	if events[0].CPUUsage == nil || *events[0].CPUUsage != cpu {
+		t.Errorf("row 0: cpu_usage = %v, want %v", events[0].CPUUsage, cpu)
+	}
+	if events[1].CPUUsage != nil {
+		t.Errorf("row 1: cpu_usage = %v, want null", *events[1].CPUUsage)
+	}
+}
+
+func TestColumnarBatchRejectsShortArray(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/alerts/columnar", receiveColumnarAlerts)
+
+	body := `{"module":"host","count":3,"service_names":["a","b","c"],"event_names":["e","e"]}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/alerts/columnar", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	var resp map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !strings.Contains(resp["error"], "event_names") {
+		t.Errorf("error = %q, want it to name event_names", resp["error"])
+	}
+}
+
+func TestColumnarBatchSkipsOmittedArrays(t *testing.T) {
+	batch := columnarBatch{
+		Module:       "redis",
+		Count:        2,
+		ServiceNames: []string{"cache", "cache"},
+		EventNames:   []string{"big_keys", "big_keys"},
+	}
+	if err := batch.checkLengths(); err != nil {
+		t.Fatalf("checkLengths with omitted arrays: %v", err)
+	}
+	for i, event := range batch.events() {
+		if event.HostIP != "" || event.AlertType != "" || !event.Timestamp.IsZero() {
+			t.Errorf("row %d: omitted columns were set: %+v", i, event.AlertCommon)
+		}
+		if event.BigKeysCount != nil {
+			t.Errorf("row %d: big_keys_count = %d, want unset", i, *event.BigKeysCount)
+		}
+	}
+}
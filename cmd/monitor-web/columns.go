@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm/schema"
+)
+
+// moduleModels maps each module with a dedicated table to its model
+var moduleModels = map[string]interface{}{
+	"redis":  RedisAlert{},
+	"mysql":  MySQLAlert{},
+	"host":   HostAlert{},
+	"system": SystemAlert{},
+}
+
+// columnDef describes one column of a module's alerts table for rendering
+type columnDef struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+}
+
+// moduleColumns returns the column definitions for module, derived from its model:
+// the shared Alert columns followed by the module-specific ones. Modules without a
+// dedicated model fall back to the shared columns.
+func moduleColumns(module string) []columnDef {
+	model, ok := moduleModels[module]
+	if !ok {
+		model = Alert{}
+	}
+	return structColumns(reflect.TypeOf(model))
+}
+
+// structColumns flattens t's fields, including embedded structs, into column definitions
+func structColumns(t reflect.Type) []columnDef {
+	naming := schema.NamingStrategy{}
+	var cols []columnDef
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			cols = append(cols, structColumns(f.Type)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		cols = append(cols, columnDef{
+			Key:   naming.ColumnName("", f.Name),
+			Label: fieldLabel(f.Name),
+			Type:  columnType(f.Type),
+		})
+	}
+	return cols
+}
+
+// recordJSON marshals a model as a flat object keyed by column name, so the embedded
+// Alert fields sit at the top level exactly as they do in the map-based read paths
+func recordJSON(model interface{}) ([]byte, error) {
+	out := make(map[string]interface{})
+	recordFields(reflect.ValueOf(model), out)
+	return json.Marshal(out)
+}
+
+// recordFields copies v's fields, including embedded structs, into out by column name,
+// converting timestamps to UTC
+func recordFields(v reflect.Value, out map[string]interface{}) {
+	naming := schema.NamingStrategy{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			recordFields(v.Field(i), out)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		value := v.Field(i).Interface()
+		switch ts := value.(type) {
+		case time.Time:
+			value = ts.UTC()
+		case *time.Time:
+			if ts != nil {
+				value = ts.UTC()
+			}
+		}
+		out[naming.ColumnName("", f.Name)] = value
+	}
+}
+
+// parseRecordJSON is the inverse of recordJSON: it fills model, a pointer to an alerts table
+// model, from a flat object keyed by column name, and returns the keys no column matches.
+// Null values leave their column at its zero value.
+func parseRecordJSON(data []byte, model interface{}) ([]string, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	if err := setRecordFields(reflect.ValueOf(model).Elem(), obj); err != nil {
+		return nil, err
+	}
+	unknown := make([]string, 0, len(obj))
+	for key := range obj {
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+// setRecordFields fills v's fields, including embedded structs, from obj by column name,
+// removing each key it consumes
+func setRecordFields(v reflect.Value, obj map[string]json.RawMessage) error {
+	naming := schema.NamingStrategy{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if err := setRecordFields(v.Field(i), obj); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		column := naming.ColumnName("", f.Name)
+		raw, ok := obj[column]
+		if !ok {
+			continue
+		}
+		delete(obj, column)
+		if string(raw) == "null" {
+			continue
+		}
+		if err := json.Unmarshal(raw, v.Field(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("column %s: %w", column, err)
+		}
+	}
+	return nil
+}
+
+// newModuleModel returns a pointer to a zero value of module's alerts table model
+func newModuleModel(module string) alertRecord {
+	model, ok := moduleModels[module]
+	if !ok {
+		model = Alert{}
+	}
+	return reflect.New(reflect.TypeOf(model)).Interface().(alertRecord)
+}
+
+// MarshalJSON flattens the alert into its column names
+func (a Alert) MarshalJSON() ([]byte, error) { return recordJSON(a) }
+
+// MarshalJSON flattens the embedded Alert so clients never see an "Alert" key
+func (a RedisAlert) MarshalJSON() ([]byte, error) { return recordJSON(a) }
+
+// MarshalJSON flattens the embedded Alert so clients never see an "Alert" key
+func (a MySQLAlert) MarshalJSON() ([]byte, error) { return recordJSON(a) }
+
+// MarshalJSON flattens the embedded Alert so clients never see an "Alert" key
+func (a HostAlert) MarshalJSON() ([]byte, error) { return recordJSON(a) }
+
+// MarshalJSON flattens the embedded Alert so clients never see an "Alert" key
+func (a SystemAlert) MarshalJSON() ([]byte, error) { return recordJSON(a) }
+
+// columnType classifies a Go field type for display
+func columnType(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Time{}) {
+		return "datetime"
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	default:
+		return "json"
+	}
+}
+
+// fieldLabel turns a Go field name such as "CPUUsage" into "CPU Usage"
+func fieldLabel(name string) string {
+	runes := []rune(name)
+	var out []rune
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			// A trailing "s" pluralises an acronym ("HostIPs") rather than starting a word
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1]) &&
+				!(i+2 == len(runes) && runes[i+1] == 's')
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				out = append(out, ' ')
+			}
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
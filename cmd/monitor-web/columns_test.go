@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordTimestampsMarshalInUTC(t *testing.T) {
+	local := time.Date(2024, 5, 1, 20, 30, 0, 0, time.FixedZone("CST", 8*3600))
+	raw, err := json.Marshal(Alert{Module: "general", Timestamp: local, ResolvedAt: &local})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, want := range []string{`"timestamp":"2024-05-01T12:30:00Z"`, `"resolved_at":"2024-05-01T12:30:00Z"`} {
+		if !strings.Contains(string(raw), want) {
+			t.Errorf("alert = %s, want it to contain %s", raw, want)
+		}
+	}
+}
+
+func TestRedisAlertMarshalsFlat(t *testing.T) {
+	alert := RedisAlert{
+		Alert:        Alert{Module: "redis", HostIP: "10.0.0.1", Timestamp: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+		BigKeysCount: 12,
+	}
+	raw, err := json.Marshal(alert)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if _, ok := fields["Alert"]; ok {
+		t.Errorf("alert has an Alert key: %s", raw)
+	}
+	if fields["timestamp"] != "2024-05-01T12:00:00Z" || fields["host_ip"] != "10.0.0.1" {
+		t.Errorf("timestamp/host_ip not at the top level: %s", raw)
+	}
+	// The promoted Alert.MarshalJSON would drop the redis columns
+	if fields["big_keys_count"] != float64(12) {
+		t.Errorf("big_keys_count = %v, want 12: %s", fields["big_keys_count"], raw)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// compareRange is one side of a comparison: the alerts in [From, To) and their split by severity
+type compareRange struct {
+	From       time.Time        `json:"from"`
+	To         time.Time        `json:"to"`
+	Total      int64            `json:"total"`
+	BySeverity map[string]int64 `json:"bySeverity"`
+}
+
+// severityCount is the number of alerts of one severity (alert_type) in a range
+type severityCount struct {
+	Severity string
+	Count    int64
+}
+
+// getAlertCompare godoc
+// @Summary Compare alert counts between two time ranges
+// @Description Counts a module's alerts in range a and range b, in total and per severity (alert_type), and returns the percentage change from b to a, e.g. this week (a) against last week (b). Ranges include their from day and exclude their to day. Deltas are null when b has no alerts. The other alert filters apply to both ranges.
+// @Tags alerts
+// @Produce json
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param a_from query string true "Start of range a (YYYY-MM-DD)"
+// @Param a_to query string true "End of range a, exclusive (YYYY-MM-DD)"
+// @Param b_from query string true "Start of range b (YYYY-MM-DD)"
+// @Param b_to query string true "End of range b, exclusive (YYYY-MM-DD)"
+// @Param alert_type query string false "Alert type filter"
+// @Param host_ip query string false "Host IP filter"
+// @Param owner_team query string false "Owner team filter"
+// @Param environment query string false "Host environment filter (from CMDB_SOURCE)"
+// @Param role query string false "Host role filter (from CMDB_SOURCE)"
+// @Param host_owner query string false "Host owner filter (from CMDB_SOURCE)"
+// @Param q query string false "Search text in details (substring match, or full-text match with ENABLE_FULLTEXT)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/compare [get]
+func getAlertCompare(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+
+	ranges := make([]*compareRange, 0, 2)
+	for _, side := range []string{"a", "b"} {
+		from, errFrom := time.Parse("2006-01-02", c.Query(side+"_from"))
+		to, errTo := time.Parse("2006-01-02", c.Query(side+"_to"))
+		if errFrom != nil || errTo != nil || !to.After(from) {
+			slog.Warn("Invalid comparison range", "range", side, "from", c.Query(side+"_from"), "to", c.Query(side+"_to"), "component", "monitor-web")
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid range " + side})
+			return
+		}
+		ranges = append(ranges, &compareRange{From: from, To: to, BySeverity: map[string]int64{}})
+	}
+
+	for _, r := range ranges {
+		var counts []severityCount
+		query := applyFieldFilters(c, db.Table(tableName)).
+			Select("alert_type AS severity, COUNT(*) AS count").
+			Where("timestamp >= ? AND timestamp < ?", r.From, r.To).
+			Group("alert_type")
+		if err := query.Scan(&counts).Error; err != nil {
+			slog.Error("Failed to count alerts for comparison", "module", module, "error", err, "component", "monitor-web")
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+			return
+		}
+		for _, sc := range counts {
+			severity := sc.Severity
+			if severity == "" {
+				severity = unspecifiedSeverity
+			}
+			r.BySeverity[severity] += sc.Count
+			r.Total += sc.Count
+		}
+	}
+	a, b := ranges[0], ranges[1]
+
+	severityDelta := make(map[string]*float64)
+	for severity := range a.BySeverity {
+		severityDelta[severity] = percentDelta(a.BySeverity[severity], b.BySeverity[severity])
+	}
+	for severity := range b.BySeverity {
+		severityDelta[severity] = percentDelta(a.BySeverity[severity], b.BySeverity[severity])
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"module":                 module,
+		"a":                      a,
+		"b":                      b,
+		"deltaPercent":           percentDelta(a.Total, b.Total),
+		"deltaPercentBySeverity": severityDelta,
+	})
+}
+
+// percentDelta is the percentage change from base to value, or nil when base is zero
+func percentDelta(value, base int64) *float64 {
+	if base == 0 {
+		return nil
+	}
+	delta := float64(value-base) / float64(base) * 100
+	return &delta
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// limitConcurrency bounds in-flight requests to max, answering 503 with Retry-After
+// once the limit is reached instead of letting requests queue on the DB pool
+func limitConcurrency(max int) gin.HandlerFunc {
+	slots := make(chan struct{}, max)
+	return func(c *gin.Context) {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			c.Next()
+		default:
+			slog.Warn("Rejected request over concurrency limit", "limit", max, "path", c.Request.URL.Path, "client_ip", c.ClientIP(), "component", "monitor-web")
+			c.Header("Retry-After", "1")
+			respondJSON(c, http.StatusServiceUnavailable, gin.H{"error": "Server busy, retry later"})
+			c.Abort()
+		}
+	}
+}
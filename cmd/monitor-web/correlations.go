@@ -0,0 +1,87 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultCorrelationTop is how many event pairs the correlation report lists by default
+const defaultCorrelationTop = 20
+
+// eventCorrelation is a pair of event_names and how often they fired on the same host in
+// the same window
+type eventCorrelation struct {
+	EventA        string `json:"event_a"`
+	EventB        string `json:"event_b"`
+	CoOccurrences int64  `json:"co_occurrences"`
+	Hosts         int64  `json:"hosts"`
+}
+
+// getAlertCorrelations godoc
+// @Summary Get which events tend to fire together
+// @Description For root-cause analysis: across every module, buckets the alerts matching the filters into fixed windows per host and counts, for each pair of event_names, the host windows in which both fired, most frequent first. Windows are aligned to the Unix epoch, so two alerts straddling a window boundary do not count as co-occurring.
+// @Tags alerts
+// @Produce json
+// @Param window query string false "Co-occurrence window (e.g., 5m, 1h)" default(5m)
+// @Param from query string false "Start date (YYYY-MM-DD); defaults to now minus DEFAULT_RANGE when from and to are omitted"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param host_ip query string false "Host IP filter (matches host_ip or any of host_ips)"
+// @Param alert_type query string false "Alert type filter"
+// @Param limit query int false "Pairs to list, at most MAX_PAGE_SIZE" default(20)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/correlations [get]
+func getAlertCorrelations(c *gin.Context) {
+	windowParam := c.DefaultQuery("window", "5m")
+	window, err := parseDuration(windowParam)
+	if err != nil || window < time.Second {
+		slog.Warn("Invalid correlation window", "window", windowParam, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid window"})
+		return
+	}
+	limit, err := parseLimit(c, defaultCorrelationTop)
+	if err != nil {
+		slog.Warn("Invalid correlation limit", "limit", c.Query("limit"), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	seconds := int64(window / time.Second)
+	var parts []string
+	var args []interface{}
+	var appliedRange gin.H
+	for _, module := range storedModules() {
+		table, _ := moduleTable(module)
+		query, rng := applyAlertFilters(c, db.Table(table).
+			Select("event_name, host_ip, FLOOR(UNIX_TIMESTAMP(timestamp) / ?) AS bucket", seconds))
+		appliedRange = rng
+		parts = append(parts, "(?)")
+		args = append(args, query)
+	}
+
+	// The same event firing repeatedly in one host window counts once
+	sql := "WITH occurrences AS (SELECT DISTINCT event_name, host_ip, bucket FROM (" + strings.Join(parts, " UNION ALL ") + ") AS alerts) " +
+		"SELECT a.event_name AS event_a, b.event_name AS event_b, COUNT(*) AS co_occurrences, COUNT(DISTINCT a.host_ip) AS hosts " +
+		"FROM occurrences a JOIN occurrences b ON a.host_ip = b.host_ip AND a.bucket = b.bucket AND a.event_name < b.event_name " +
+		"GROUP BY a.event_name, b.event_name ORDER BY co_occurrences DESC, event_a, event_b LIMIT ?"
+	pairs := []eventCorrelation{}
+	if err := db.Raw(sql, append(args, limit)...).Scan(&pairs).Error; err != nil {
+		slog.Error("Failed to query alert correlations", "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query correlations"})
+		return
+	}
+
+	resp := gin.H{
+		"window": windowParam,
+		"pairs":  pairs,
+	}
+	if appliedRange != nil {
+		resp["defaultRange"] = appliedRange
+	}
+	respondJSON(c, http.StatusOK, resp)
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// maxCSVUploadBytes bounds a CSV import body
+const maxCSVUploadBytes = 64 << 20
+
+// csvTimeFormats are the timestamp layouts a CSV cell may use; those without a zone are UTC
+var csvTimeFormats = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// csvUnsupportedColumns are event fields a CSV import cannot carry
+var csvUnsupportedColumns = map[string]bool{"fan_out": true, "attachment": true}
+
+// importAlertsCSV godoc
+// @Summary Import a module's alerts from a CSV file
+// @Description Imports historical alerts from a CSV upload, sent as the multipart field "file" or as the raw text/csv body (optionally gzip-compressed). The header row names the columns, by column key (event_name) or by the labels the xlsx export and /schema use (Event Name), case-insensitively; columns that are not alert fields (such as id or status) are ignored, or rejected under STRICT_SCHEMA. Timestamps are RFC 3339 or "YYYY-MM-DD hh:mm:ss" in UTC, host_ips a JSON array or comma-separated list, and labels a JSON object. Each row is validated like POST /alerts and inserted in batches like POST /alerts/import, whose summary is returned with the failing line numbers. With dry_run=true rows are only validated and counted as valid. fan_out and attachment columns are not supported.
+// @Tags alerts
+// @Accept text/csv
+// @Accept multipart/form-data
+// @Produce json
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param file formData file false "CSV file, when uploading as multipart/form-data"
+// @Param dry_run query bool false "Validate the rows without storing them"
+// @Param X-Ingest-Key header string false "Shared secret for the module, required when INGEST_KEY or INGEST_KEYS covers it"
+// @Success 200 {object} importSummary
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /alerts/{module}/import.csv [post]
+func importAlertsCSV(c *gin.Context) {
+	module := moduleParam(c)
+	if _, ok := moduleTable(module); !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+
+	body, err := csvUploadBody(c)
+	if err != nil {
+		slog.Error("Failed to open CSV import body", "module", module, "error", err, "client_ip", c.ClientIP(), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid CSV upload"})
+		return
+	}
+	defer body.Close()
+
+	reader := csv.NewReader(io.LimitReader(body, maxCSVUploadBytes))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		slog.Warn("Failed to read CSV header", "module", module, "error", err, "client_ip", c.ClientIP(), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Missing CSV header"})
+		return
+	}
+	columns, err := csvColumns(module, header)
+	if err != nil {
+		slog.Warn("Rejected CSV header", "module", module, "error", err, "client_ip", c.ClientIP(), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	summary := &importSummary{Failures: []importFailure{}}
+	fields := jsonFields(reflect.TypeOf(AlertEvent{}))
+	batch := make([]importRow, 0, importBatchSize)
+	for {
+		cells, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				// Keep what was stored so far and report where the upload broke off
+				summary.fail(summary.Lines+2, "read error: "+err.Error())
+				break
+			}
+			summary.Lines++
+			summary.fail(parseErr.Line, "invalid CSV: "+parseErr.Err.Error())
+			continue
+		}
+		summary.Lines++
+		line, _ := reader.FieldPos(0)
+		event, err := csvEvent(module, columns, fields, cells)
+		if err != nil {
+			summary.fail(line, err.Error())
+			continue
+		}
+		if dryRun {
+			if validateImportEvent(c, &event, line, summary) != nil {
+				summary.Valid++
+			}
+			continue
+		}
+		record := stageImportEvent(c, &event, line, summary)
+		if record == nil {
+			continue
+		}
+		batch = append(batch, importRow{line: line, record: record})
+		if len(batch) == importBatchSize {
+			flushImportBatch(batch, summary)
+			batch = batch[:0]
+		}
+	}
+	flushImportBatch(batch, summary)
+
+	slog.Info("Imported CSV alerts", "module", module, "rows", summary.Lines, "stored", summary.Stored, "resolved", summary.Resolved, "valid", summary.Valid, "failed", summary.Failed, "dry_run", dryRun, "client_ip", c.ClientIP(), "component", "monitor-web")
+	respondJSON(c, http.StatusOK, summary)
+}
+
+// csvUploadBody returns the uploaded CSV: the multipart field "file", or else the request body
+func csvUploadBody(c *gin.Context) (io.ReadCloser, error) {
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		return file.Open()
+	}
+	return decompressedBody(c.Request)
+}
+
+// csvColumns maps each header cell to the AlertEvent JSON field it fills; "" skips the column.
+// Cells match a column key or a moduleColumns label, case-insensitively.
+func csvColumns(module string, header []string) ([]string, error) {
+	fields := jsonFields(reflect.TypeOf(AlertEvent{}))
+	labels := make(map[string]string)
+	for _, col := range moduleColumns(module) {
+		labels[strings.ToLower(col.Label)] = col.Key
+	}
+
+	columns := make([]string, len(header))
+	seen := make(map[string]bool)
+	var unknown []string
+	for i, cell := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cell, "\ufeff")))
+		if name == "" {
+			continue
+		}
+		key, ok := labels[name]
+		if !ok {
+			key = strings.ReplaceAll(name, " ", "_")
+		}
+		if csvUnsupportedColumns[key] {
+			return nil, fmt.Errorf("Unsupported column: %s", cell)
+		}
+		if _, ok := fields[key]; !ok {
+			unknown = append(unknown, cell)
+			continue
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("Duplicate column: %s", cell)
+		}
+		seen[key] = true
+		columns[i] = key
+	}
+	if len(unknown) > 0 && viper.GetBool("STRICT_SCHEMA") {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("Unknown columns: %s", strings.Join(unknown, ", "))
+	}
+	return columns, nil
+}
+
+// csvEvent builds the event of one CSV row. Empty cells leave their field unset.
+func csvEvent(module string, columns []string, fields map[string]reflect.Type, cells []string) (AlertEvent, error) {
+	var event AlertEvent
+	if len(cells) != len(columns) {
+		return event, fmt.Errorf("row has %d cells, header has %d", len(cells), len(columns))
+	}
+	obj := make(map[string]interface{})
+	for i, key := range columns {
+		cell := strings.TrimSpace(cells[i])
+		if key == "" || cell == "" {
+			continue
+		}
+		value, err := csvValue(cell, fields[key])
+		if err != nil {
+			return event, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		obj[key] = value
+	}
+	if m, ok := obj["module"].(string); ok && canonicalModule(m) != module {
+		return event, fmt.Errorf("module %q does not match %s", m, module)
+	}
+	obj["module"] = module
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return event, err
+	}
+	err = json.Unmarshal(raw, &event)
+	return event, err
+}
+
+// csvValue converts a cell into the JSON value of an AlertEvent field of type t
+func csvValue(cell string, t reflect.Type) (interface{}, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		for _, layout := range csvTimeFormats {
+			if ts, err := time.ParseInLocation(layout, cell, time.UTC); err == nil {
+				return ts, nil
+			}
+		}
+		return nil, fmt.Errorf("unrecognized timestamp %q", cell)
+	case t.Kind() == reflect.Slice:
+		if strings.HasPrefix(cell, "[") {
+			return csvJSON(cell)
+		}
+		var items []string
+		for _, item := range strings.Split(cell, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	case t.Kind() == reflect.Map:
+		return csvJSON(cell)
+	case t.Kind() == reflect.Int || t.Kind() == reflect.Int64:
+		return strconv.ParseInt(cell, 10, 64)
+	case t.Kind() == reflect.Float64:
+		return strconv.ParseFloat(cell, 64)
+	}
+	return cell, nil
+}
+
+// csvJSON passes a cell holding JSON through unchanged
+func csvJSON(cell string) (interface{}, error) {
+	if !json.Valid([]byte(cell)) {
+		return nil, fmt.Errorf("invalid JSON %q", cell)
+	}
+	return json.RawMessage(cell), nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SchemaMigration records a data migration that has been applied
+type SchemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// dataMigrations transform existing rows after AutoMigrate has brought the tables up to
+// date. A migration's version is its 1-based position, so new ones are only ever appended;
+// each runs once, in its own transaction, and is recorded in schema_migrations.
+var dataMigrations = []func(*gorm.DB) error{
+	foldStoredAlertTypes, // 1
+}
+
+// pendingDataMigrations returns the versions of the data migrations not yet applied
+func pendingDataMigrations() ([]int, error) {
+	applied := map[int]bool{}
+	if db.Migrator().HasTable(&SchemaMigration{}) {
+		var versions []int
+		if err := db.Model(&SchemaMigration{}).Pluck("version", &versions).Error; err != nil {
+			return nil, fmt.Errorf("failed to read applied data migrations: %w", err)
+		}
+		for _, v := range versions {
+			applied[v] = true
+		}
+	}
+	var pending []int
+	for i := range dataMigrations {
+		if !applied[i+1] {
+			pending = append(pending, i+1)
+		}
+	}
+	return pending, nil
+}
+
+// runDataMigrations applies the pending data migrations in version order. The version row
+// is inserted before the migration runs, so an instance starting concurrently blocks on it
+// and then skips the migration instead of applying it twice.
+func runDataMigrations() error {
+	pending, err := pendingDataMigrations()
+	if err != nil {
+		return err
+	}
+	for _, version := range pending {
+		skipped := false
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&SchemaMigration{Version: version, AppliedAt: time.Now().UTC()}).Error; err != nil {
+				if isDuplicateKeyError(err) {
+					skipped = true
+					return nil
+				}
+				return err
+			}
+			return dataMigrations[version-1](tx)
+		})
+		if err != nil {
+			return fmt.Errorf("data migration %d failed: %w", version, err)
+		}
+		if skipped {
+			slog.Info("Data migration already applied by another instance", "version", version, "component", "monitor-web")
+			continue
+		}
+		slog.Info("Applied data migration", "version", version, "component", "monitor-web")
+	}
+	return nil
+}
+
+// foldStoredAlertTypes folds the alert_type of rows stored before alert types were
+// normalized onto the canonical ALERT_TYPES, keeping the value as sent in raw_alert_type
+func foldStoredAlertTypes(tx *gorm.DB) error {
+	for _, model := range alertModels {
+		var raw []string
+		query := tx.Model(model).Where("raw_alert_type IS NULL OR raw_alert_type = ''")
+		if err := query.Distinct().Pluck("alert_type", &raw).Error; err != nil {
+			return err
+		}
+		for _, value := range raw {
+			err := tx.Model(model).
+				Where("(raw_alert_type IS NULL OR raw_alert_type = '') AND alert_type = ?", value).
+				Updates(map[string]interface{}{"alert_type": normalizeAlertType(value), "raw_alert_type": value}).Error
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// stubDataMigrations replaces dataMigrations with count no-op migrations for the duration
+// of the test, except that version fail returns an error, and returns the versions run
+func stubDataMigrations(t *testing.T, count, fail int) *[]int {
+	t.Helper()
+	var ran []int
+	prev := dataMigrations
+	dataMigrations = nil
+	for i := 1; i <= count; i++ {
+		version := i
+		dataMigrations = append(dataMigrations, func(*gorm.DB) error {
+			ran = append(ran, version)
+			if version == fail {
+				return fmt.Errorf("migration %d broke", version)
+			}
+			return nil
+		})
+	}
+	t.Cleanup(func() { dataMigrations = prev })
+	return &ran
+}
+
+// expectAppliedVersions expects pendingDataMigrations to find schema_migrations holding versions
+func expectAppliedVersions(mock sqlmock.Sqlmock, versions ...int) {
+	mock.ExpectQuery("SELECT DATABASE\\(\\)").WillReturnRows(sqlmock.NewRows([]string{"DATABASE()"}).AddRow("monitor"))
+	mock.ExpectQuery("Information_schema.SCHEMATA").WillReturnRows(sqlmock.NewRows([]string{"SCHEMA_NAME"}).AddRow("monitor"))
+	mock.ExpectQuery("FROM information_schema.tables").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	rows := sqlmock.NewRows([]string{"version"})
+	for _, v := range versions {
+		rows.AddRow(v)
+	}
+	mock.ExpectQuery("SELECT `version` FROM `schema_migrations`").WillReturnRows(rows)
+}
+
+// expectVersionInsert expects the transaction that records version before running it
+func expectVersionInsert(mock sqlmock.Sqlmock, version int) {
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `schema_migrations`").
+		WithArgs(version, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(int64(version), 1))
+}
+
+func TestRunDataMigrationsAppliesPendingInOrder(t *testing.T) {
+	loadTestConfig(t, nil)
+	mock := mockDB(t)
+	ran := stubDataMigrations(t, 4, 0)
+
+	// Version 2 is already recorded, so only 1, 3 and 4 run
+	expectAppliedVersions(mock, 2)
+	for _, version := range []int{1, 3, 4} {
+		expectVersionInsert(mock, version)
+		mock.ExpectCommit()
+	}
+	if err := runDataMigrations(); err != nil {
+		t.Fatalf("runDataMigrations: %v", err)
+	}
+	if want := []int{1, 3, 4}; !reflect.DeepEqual(*ran, want) {
+		t.Errorf("ran %v, want %v", *ran, want)
+	}
+}
+
+func TestRunDataMigrationsSkipsAllApplied(t *testing.T) {
+	loadTestConfig(t, nil)
+	mock := mockDB(t)
+	ran := stubDataMigrations(t, 2, 0)
+
+	expectAppliedVersions(mock, 1, 2)
+	if err := runDataMigrations(); err != nil {
+		t.Fatalf("runDataMigrations: %v", err)
+	}
+	if len(*ran) != 0 {
+		t.Errorf("ran %v, want none", *ran)
+	}
+}
+
+func TestRunDataMigrationsSkipsVersionRecordedConcurrently(t *testing.T) {
+	loadTestConfig(t, nil)
+	mock := mockDB(t)
+	ran := stubDataMigrations(t, 2, 0)
+
+	// Another instance records version 1 between the read and the insert
+	expectAppliedVersions(mock)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `schema_migrations`").WithArgs(1, sqlmock.AnyArg()).
+		WillReturnError(&mysqldriver.MySQLError{Number: mysqlDuplicateEntry, Message: "Duplicate entry '1' for key 'PRIMARY'"})
+	mock.ExpectCommit()
+	expectVersionInsert(mock, 2)
+	mock.ExpectCommit()
+	if err := runDataMigrations(); err != nil {
+		t.Fatalf("runDataMigrations: %v", err)
+	}
+	if want := []int{2}; !reflect.DeepEqual(*ran, want) {
+		t.Errorf("ran %v, want %v", *ran, want)
+	}
+}
+
+func TestRunDataMigrationsRollsBackFailedVersion(t *testing.T) {
+	loadTestConfig(t, nil)
+	mock := mockDB(t)
+	ran := stubDataMigrations(t, 3, 2)
+
+	// Version 2 fails, so its row is rolled back and version 3 never runs
+	expectAppliedVersions(mock)
+	expectVersionInsert(mock, 1)
+	mock.ExpectCommit()
+	expectVersionInsert(mock, 2)
+	mock.ExpectRollback()
+	err := runDataMigrations()
+	if err == nil {
+		t.Fatal("runDataMigrations succeeded, want migration 2's failure")
+	}
+	if want := "data migration 2 failed: migration 2 broke"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+	if want := []int{1, 2}; !reflect.DeepEqual(*ran, want) {
+		t.Errorf("ran %v, want %v", *ran, want)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+const (
+	mysqlDuplicateEntry     = 1062    // ER_DUP_ENTRY
+	postgresUniqueViolation = "23505" // unique_violation
+	postgresDataException   = "22"    // SQLSTATE class of invalid values, e.g. string_data_right_truncation
+)
+
+// mysqlTransientErrors are the server errors an insert can succeed after, once the server
+// has recovered or the conflicting transaction has finished
+var mysqlTransientErrors = map[uint16]bool{
+	1040: true, // ER_CON_COUNT_ERROR, too many connections
+	1053: true, // ER_SERVER_SHUTDOWN
+	1205: true, // ER_LOCK_WAIT_TIMEOUT
+	1213: true, // ER_LOCK_DEADLOCK
+	1290: true, // ER_OPTION_PREVENTS_STATEMENT, e.g. --read-only during a failover
+	1792: true, // ER_CANT_EXECUTE_IN_READ_ONLY_TRANSACTION
+}
+
+// mysqlDataErrors are the server errors for a value that does not fit its column
+var mysqlDataErrors = map[uint16]bool{
+	1048: true, // ER_BAD_NULL_ERROR
+	1264: true, // ER_WARN_DATA_OUT_OF_RANGE
+	1292: true, // ER_TRUNCATED_WRONG_VALUE
+	1366: true, // ER_TRUNCATED_WRONG_VALUE_FOR_FIELD
+	1406: true, // ER_DATA_TOO_LONG
+}
+
+// postgresTransientClasses are the SQLSTATE classes of connection, resource and
+// serialization failures
+var postgresTransientClasses = []string{"08", "40", "53", "57"}
+
+// classifyDBError maps an insert error to the HTTP status and client-facing message to return
+func classifyDBError(err error) (int, string) {
+	switch {
+	case isDuplicateKeyError(err):
+		return http.StatusConflict, "Alert already exists"
+	case isDataError(err):
+		return http.StatusBadRequest, "Alert does not fit the schema: a field is too long or out of range"
+	case isTransientDBError(err):
+		return http.StatusServiceUnavailable, "Database unavailable, retry later"
+	}
+	return http.StatusInternalServerError, "Failed to store alert"
+}
+
+// isTransientDBError reports whether err is a connection or availability failure that the
+// same insert can succeed after, as opposed to an error the server will always return for it
+func isTransientDBError(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlTransientErrors[mysqlErr.Number]
+	}
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		for _, class := range postgresTransientClasses {
+			if strings.HasPrefix(pgErr.SQLState(), class) {
+				return true
+			}
+		}
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, mysqldriver.ErrInvalidConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isDataError reports whether err is the server rejecting a value that does not fit its column
+func isDataError(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlDataErrors[mysqlErr.Number]
+	}
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		return strings.HasPrefix(pgErr.SQLState(), postgresDataException)
+	}
+	return false
+}
+
+// isDuplicateKeyError reports whether err is a primary-key or unique-constraint violation
+func isDuplicateKeyError(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDuplicateEntry
+	}
+	// Postgres drivers (pgconn.PgError) expose the SQLSTATE code via SQLState()
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		return pgErr.SQLState() == postgresUniqueViolation
+	}
+	return false
+}
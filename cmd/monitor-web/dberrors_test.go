@@ -0,0 +1,47 @@
+package main
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+)
+
+// sqlStateError simulates a Postgres driver error such as pgconn.PgError
+type sqlStateError struct{ code string }
+
+func (e *sqlStateError) Error() string    { return "SQLSTATE " + e.code }
+func (e *sqlStateError) SQLState() string { return e.code }
+
+func TestClassifyDBError(t *testing.T) {
+	duplicate := &mysqldriver.MySQLError{Number: mysqlDuplicateEntry, Message: "Duplicate entry '1' for key 'PRIMARY'"}
+	tests := []struct {
+		name      string
+		err       error
+		duplicate bool
+		status    int
+	}{
+		{"mysql duplicate entry", duplicate, true, http.StatusConflict},
+		{"wrapped mysql duplicate entry", fmt.Errorf("insert alert: %w", duplicate), true, http.StatusConflict},
+		{"postgres unique violation", &sqlStateError{postgresUniqueViolation}, true, http.StatusConflict},
+		{"generic error", errors.New("connection refused"), false, http.StatusInternalServerError},
+		{"mysql data too long", &mysqldriver.MySQLError{Number: 1406, Message: "Data too long for column 'service_name'"}, false, http.StatusBadRequest},
+		{"postgres value too long", &sqlStateError{"22001"}, false, http.StatusBadRequest},
+		{"mysql read-only", &mysqldriver.MySQLError{Number: 1290, Message: "The MySQL server is running with the --read-only option"}, false, http.StatusServiceUnavailable},
+		{"bad connection", fmt.Errorf("insert alert: %w", driver.ErrBadConn), false, http.StatusServiceUnavailable},
+		{"postgres connection failure", &sqlStateError{"08006"}, false, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDuplicateKeyError(tt.err); got != tt.duplicate {
+				t.Errorf("isDuplicateKeyError = %v, want %v", got, tt.duplicate)
+			}
+			if status, _ := classifyDBError(tt.err); status != tt.status {
+				t.Errorf("classifyDBError status = %d, want %d", status, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// slowQueryThreshold is how long a query may run before it is logged as slow at warn
+const slowQueryThreshold = 200 * time.Millisecond
+
+// dbLogLevels maps DB_LOG_LEVEL values to gorm log levels
+var dbLogLevels = map[string]logger.LogLevel{
+	"silent": logger.Silent,
+	"error":  logger.Error,
+	"warn":   logger.Warn,
+	"info":   logger.Info,
+}
+
+// parseDBLogLevel returns the gorm log level named by s
+func parseDBLogLevel(s string) (logger.LogLevel, error) {
+	level, ok := dbLogLevels[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return 0, fmt.Errorf("unknown level %q: want silent, error, warn or info", s)
+	}
+	return level, nil
+}
+
+// slogGormLogger routes gorm's logs through slog so SQL shows up as structured log lines
+type slogGormLogger struct {
+	level logger.LogLevel
+}
+
+func (l slogGormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return slogGormLogger{level: level}
+}
+
+func (l slogGormLogger) Info(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Info {
+		slog.Info(fmt.Sprintf(msg, args...), "component", "monitor-web")
+	}
+}
+
+func (l slogGormLogger) Warn(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Warn {
+		slog.Warn(fmt.Sprintf(msg, args...), "component", "monitor-web")
+	}
+}
+
+func (l slogGormLogger) Error(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= logger.Error {
+		slog.Error(fmt.Sprintf(msg, args...), "component", "monitor-web")
+	}
+}
+
+// Trace logs failed queries at error, slow ones at warn and every query at info
+func (l slogGormLogger) Trace(_ context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= logger.Error:
+		sql, rows := fc()
+		slog.Error("Database query failed", "sql", sql, "rows", rows, "elapsed_ms", elapsed.Milliseconds(), "error", err, "component", "monitor-web")
+	case elapsed > slowQueryThreshold && l.level >= logger.Warn:
+		sql, rows := fc()
+		slog.Warn("Slow database query", "sql", sql, "rows", rows, "elapsed_ms", elapsed.Milliseconds(), "threshold_ms", slowQueryThreshold.Milliseconds(), "component", "monitor-web")
+	case l.level >= logger.Info:
+		sql, rows := fc()
+		slog.Info("Database query", "sql", sql, "rows", rows, "elapsed_ms", elapsed.Milliseconds(), "component", "monitor-web")
+	}
+}
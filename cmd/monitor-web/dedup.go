@@ -0,0 +1,108 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// defaultDedupTop is how many fingerprints the dedup report lists by default
+const defaultDedupTop = 10
+
+// dedupModuleStats counts one module's stored alerts and the repeats ENFORCE_UNIQUE ignored
+type dedupModuleStats struct {
+	Module     string `json:"module"`
+	Stored     int64  `json:"stored"`
+	Suppressed int64  `json:"suppressed"`
+}
+
+// dedupFingerprint is one alert fingerprint and how often it arrived, repeats included
+type dedupFingerprint struct {
+	Module      string `json:"module"`
+	ServiceName string `json:"service_name"`
+	EventName   string `json:"event_name"`
+	HostIP      string `json:"host_ip"`
+	ClusterName string `json:"cluster_name"`
+	Occurrences int64  `json:"occurrences"`
+	Suppressed  int64  `json:"suppressed"`
+}
+
+// getDedupStats godoc
+// @Summary Report how much noise deduplication removed
+// @Description Per module, counts the alerts stored in the range and the repeats ENFORCE_UNIQUE ignored as duplicates of them, plus the fingerprints (module, service_name, event_name, host_ip, cluster_name) that arrived most often, repeats included. Repeats are attributed to the stored alert's timestamp, which they share. Repeats are only counted while ENFORCE_UNIQUE is enabled. With FILTER_CACHE_TTL set, responses are cached for that long or until any alert is stored or resolved.
+// @Tags stats
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD); defaults to now minus DEFAULT_RANGE when from and to are omitted"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param limit query int false "Fingerprints to list, at most MAX_PAGE_SIZE" default(10)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /stats/dedup [get]
+func getDedupStats(c *gin.Context) {
+	limit, err := parseLimit(c, defaultDedupTop)
+	if err != nil {
+		slog.Warn("Invalid dedup stats limit", "limit", c.Query("limit"), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+	if cachedFilterRead(c) {
+		return
+	}
+
+	var countParts, topParts []string
+	var countArgs, topArgs []interface{}
+	var appliedRange gin.H
+	for _, module := range storedModules() {
+		table, _ := moduleTable(module)
+		counts, rng := applyAlertFilters(c, db.Table(table).
+			Select("? AS module, COUNT(*) AS stored, COALESCE(SUM(duplicates), 0) AS suppressed", module))
+		appliedRange = rng
+		countParts = append(countParts, "(?)")
+		countArgs = append(countArgs, counts)
+
+		top, _ := applyAlertFilters(c, db.Table(table).
+			Select("module, service_name, event_name, host_ip, cluster_name, COUNT(*) + COALESCE(SUM(duplicates), 0) AS occurrences, COALESCE(SUM(duplicates), 0) AS suppressed").
+			Group("module, service_name, event_name, host_ip, cluster_name").
+			Order("occurrences DESC").
+			Limit(limit))
+		topParts = append(topParts, "(?)")
+		topArgs = append(topArgs, top)
+	}
+
+	modules := []dedupModuleStats{}
+	if err := db.Raw(strings.Join(countParts, " UNION ALL "), countArgs...).Scan(&modules).Error; err != nil {
+		slog.Error("Failed to count deduplicated alerts", "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query dedup stats"})
+		return
+	}
+	// Each table contributes its own top fingerprints, so the overall top ones are among them
+	top := []dedupFingerprint{}
+	topSQL := "SELECT * FROM (" + strings.Join(topParts, " UNION ALL ") + ") AS fingerprints ORDER BY occurrences DESC, module, event_name LIMIT ?"
+	if err := db.Raw(topSQL, append(topArgs, limit)...).Scan(&top).Error; err != nil {
+		slog.Error("Failed to query top fingerprints", "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query dedup stats"})
+		return
+	}
+
+	var stored, suppressed int64
+	for _, m := range modules {
+		stored += m.Stored
+		suppressed += m.Suppressed
+	}
+	resp := gin.H{
+		"enforceUnique":   viper.GetBool("ENFORCE_UNIQUE"),
+		"modules":         modules,
+		"stored":          stored,
+		"suppressed":      suppressed,
+		"topFingerprints": top,
+	}
+	if appliedRange != nil {
+		resp["defaultRange"] = appliedRange
+	}
+	cacheFilterRead(c, "", resp)
+	respondJSON(c, http.StatusOK, resp)
+}
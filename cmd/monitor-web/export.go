@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	xlsxSheet       = "Sheet1"
+	xlsxDateFormat  = "yyyy-mm-dd hh:mm:ss"
+	xlsxMaxRows     = 1048575 // Excel's row limit, less the header row
+)
+
+// exportAlertsXLSX godoc
+// @Summary Export alerts as an Excel spreadsheet
+// @Description Streams the module's alerts matching the filters, newest first, as an xlsx workbook with a header row and one column per module column. Timestamps are written as UTC dates and numeric columns as numbers. At most 1048575 rows are exported.
+// @Tags alerts
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param from query string false "Start date (YYYY-MM-DD); defaults to now minus DEFAULT_RANGE when from and to are omitted"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param alert_type query string false "Alert type filter"
+// @Param host_ip query string false "Host IP filter (matches host_ip or any of host_ips)"
+// @Param owner_team query string false "Owning team filter (from SERVICE_OWNERS)"
+// @Param environment query string false "Host environment filter (from CMDB_SOURCE)"
+// @Param role query string false "Host role filter (from CMDB_SOURCE)"
+// @Param host_owner query string false "Host owner filter (from CMDB_SOURCE)"
+// @Param q query string false "Search text in details (substring match, or full-text match with ENABLE_FULLTEXT)"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/export.xlsx [get]
+func exportAlertsXLSX(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+
+	query, _ := applyAlertFilters(c, db.Table(tableName).Order("timestamp desc, id desc").Limit(xlsxMaxRows))
+	rows, err := query.Rows()
+	if err != nil {
+		slog.Error("Failed to query alerts for export", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+	defer rows.Close()
+
+	f := excelize.NewFile()
+	defer f.Close()
+	sw, err := f.NewStreamWriter(xlsxSheet)
+	if err != nil {
+		slog.Error("Failed to create spreadsheet", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to export alerts"})
+		return
+	}
+	headerStyle, _ := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	dateFormat := xlsxDateFormat
+	dateStyle, _ := f.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+
+	columns := moduleColumns(module)
+	header := make([]interface{}, len(columns))
+	for i, col := range columns {
+		header[i] = excelize.Cell{StyleID: headerStyle, Value: col.Label}
+	}
+	if err := sw.SetRow("A1", header); err != nil {
+		slog.Error("Failed to write spreadsheet header", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to export alerts"})
+		return
+	}
+
+	n := 1
+	for rows.Next() {
+		row := map[string]interface{}{}
+		if err := db.ScanRows(rows, &row); err != nil {
+			slog.Error("Failed to scan alert for export", "module", module, "error", err, "component", "monitor-web")
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+			return
+		}
+		n++
+		cells := make([]interface{}, len(columns))
+		for i, col := range columns {
+			cells[i] = xlsxCell(row[col.Key], col.Type, dateStyle)
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, n)
+		if err := sw.SetRow(cell, cells); err != nil {
+			slog.Error("Failed to write spreadsheet row", "module", module, "error", err, "component", "monitor-web")
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to export alerts"})
+			return
+		}
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("Failed to read alerts for export", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+	if err := sw.Flush(); err != nil {
+		slog.Error("Failed to finalize spreadsheet", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to export alerts"})
+		return
+	}
+
+	c.Header("Content-Type", xlsxContentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-alerts.xlsx"`, module))
+	c.Status(http.StatusOK)
+	if _, err := f.WriteTo(c.Writer); err != nil {
+		// Headers are already sent, so the client just sees a truncated download
+		slog.Error("Failed to stream spreadsheet", "module", module, "error", err, "component", "monitor-web")
+		return
+	}
+	slog.Info("Exported alerts", "module", module, "rows", n-1, "client_ip", c.ClientIP(), "component", "monitor-web")
+}
+
+// xlsxCell converts a scanned column value into a typed spreadsheet cell: times become UTC
+// dates, numeric columns numbers, and raw bytes (JSON columns, decimals) text
+func xlsxCell(v interface{}, colType string, dateStyle int) interface{} {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		return excelize.Cell{StyleID: dateStyle, Value: val.UTC()}
+	case *time.Time:
+		if val == nil {
+			return nil
+		}
+		return excelize.Cell{StyleID: dateStyle, Value: val.UTC()}
+	case []byte:
+		v = string(val)
+	}
+	if s, ok := v.(string); ok && colType == "number" {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	return v
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// newIncidentID returns a random ID shared by the alerts fanned out from one event
+func newIncidentID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// fanOutHosts lists the distinct hosts of event, primary host first
+func fanOutHosts(event *AlertEvent) []string {
+	var hosts []string
+	seen := make(map[string]bool)
+	for _, host := range append([]string{event.HostIP}, event.HostIPs...) {
+		if host != "" && !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// fanOutRecords copies record once per host, each carrying that host alone so per-host
+// queries match exactly one of the copies, and ties the copies together with incidentID
+func fanOutRecords(record alertRecord, hosts []string, incidentID, clientIP string) []alertRecord {
+	records := make([]alertRecord, 0, len(hosts))
+	for _, host := range hosts {
+		v := reflect.New(reflect.TypeOf(record).Elem())
+		v.Elem().Set(reflect.ValueOf(record).Elem())
+		copied := v.Interface().(alertRecord)
+		alert := copied.base()
+		alert.HostIP = host
+		alert.HostIPs = nil
+		alert.IncidentID = incidentID
+		enrichFromCMDB(alert)
+		alert.Fingerprint = alertFingerprint(alert)
+		// Muting is per host, so only the copies for muted hosts are suppressed
+		suppressed, err := isHostMuted([]string{host})
+		if err != nil {
+			slog.Warn("Failed to check host mutes", "error", err, "client_ip", clientIP, "component", "monitor-web")
+		}
+		alert.Suppressed = suppressed
+		records = append(records, copied)
+	}
+	return records
+}
+
+// storeFanOut stores or resolves one alert per host of a fan_out event, returning the
+// response for ingestEvent. Stored alerts are inserted in one transaction, so either
+// every host gets its row or none does.
+func storeFanOut(event *AlertEvent, record alertRecord, clientIP string) (int, gin.H) {
+	incidentID := newIncidentID()
+	hosts := fanOutHosts(event)
+	records := fanOutRecords(record, hosts, incidentID, clientIP)
+
+	if event.State == statusResolved {
+		ids := []uint64{}
+		for _, r := range records {
+			id, found, err := resolveAlert(r)
+			if err != nil {
+				slog.Error("Failed to resolve alert", "module", event.Module, "host_ip", r.base().HostIP, "error", err, "client_ip", clientIP, "component", "monitor-web")
+				return http.StatusInternalServerError, gin.H{"error": "Failed to resolve alert"}
+			}
+			if found {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			slog.Warn("No open alert to resolve", "module", event.Module, "event_name", event.EventName, "hosts", hosts, "client_ip", clientIP, "component", "monitor-web")
+			return http.StatusOK, gin.H{"status": "unmatched"}
+		}
+		slog.Info("Resolved fanned-out alerts", "module", event.Module, "event_name", event.EventName, "ids", ids, "client_ip", clientIP, "component", "monitor-web")
+		return http.StatusOK, gin.H{"status": statusResolved, "ids": ids}
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, r := range records {
+			if err := tx.Create(r).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("Failed to store fanned-out alerts", "module", event.Module, "hosts", len(records), "error", err, "client_ip", clientIP, "component", "monitor-web")
+		discardAttachment(record)
+		status, msg := classifyDBError(err)
+		return status, gin.H{"error": msg}
+	}
+	invalidateFilterCache(record.base().Module)
+
+	ids := make([]uint64, len(records))
+	for i, r := range records {
+		ids[i] = r.base().ID
+	}
+	slog.Info("Stored fanned-out alerts", "module", event.Module, "event_name", event.EventName, "incident_id", incidentID, "hosts", len(records), "client_ip", clientIP, "component", "monitor-web")
+	return http.StatusOK, gin.H{"status": "stored", "incidentId": incidentID, "ids": ids}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serveFavicon answers /favicon.ico from path, or with an empty 204 when no favicon is
+// configured, so browsers stop logging 404s; either answer may be cached for a day
+func serveFavicon(path string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=86400")
+		if path == "" {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		c.File(path)
+	}
+}
+
+// noStoreAPI marks API responses as uncacheable so a browser never shows alerts that
+// have since been resolved or muted
+func noStoreAPI(c *gin.Context) {
+	if strings.HasPrefix(c.Request.URL.Path, apiBasePath+"/api/") {
+		c.Header("Cache-Control", "no-store")
+	}
+	c.Next()
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// maxFeedPage bounds the per-table rows fetched for deep pages
+const maxFeedPage = 200
+
+// getFeed godoc
+// @Summary Get a chronological feed across all modules
+// @Description Merges the shared alert columns from every module table, newest first, with pagination and a total count. Each entry carries its source module.
+// @Tags alerts
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param severity query string false "Severity (alert_type) filter"
+// @Param host_ip query string false "Host IP filter (matches host_ip or any of host_ips)"
+// @Param page query int false "Page number (1-based)" default(1)
+// @Param page_size query int false "Entries per page, clamped to MAX_PAGE_SIZE" default(50)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /feed [get]
+func getFeed(c *gin.Context) {
+	page, pageSize, err := parsePagination(c)
+	if err == nil && page > maxFeedPage {
+		err = errInvalidPage
+	}
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": paginationErrorMessage(err)})
+		return
+	}
+	offset := (page - 1) * pageSize
+	severity := c.Query("severity")
+
+	// filtered applies the request filters to one module table
+	var appliedRange gin.H
+	filtered := func(table string) *gorm.DB {
+		query := db.Table(table)
+		query, appliedRange = applyAlertFilters(c, query)
+		if severity != "" {
+			query = query.Where("alert_type = ?", normalizeAlertType(severity))
+		}
+		return query
+	}
+
+	columns := strings.Join(sharedColumnNames(), ", ")
+	var parts []string
+	var args []interface{}
+	var total int64
+	for _, module := range storedModules() {
+		table, _ := moduleTable(module)
+
+		var count int64
+		if err := filtered(table).Count(&count).Error; err != nil {
+			slog.Error("Failed to count feed alerts", "module", module, "error", err, "component", "monitor-web")
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+			return
+		}
+		total += count
+
+		// Each branch only needs enough rows to fill the requested page
+		parts = append(parts, "(?)")
+		args = append(args, filtered(table).
+			Select(columns+", ? AS source", module).
+			Order("timestamp desc, id desc").
+			Limit(offset+pageSize))
+	}
+
+	// ids are only unique per table, so ties break on source before id to keep pages stable
+	entries := []map[string]interface{}{}
+	sql := "SELECT * FROM (" + strings.Join(parts, " UNION ALL ") + ") AS feed ORDER BY timestamp DESC, source, id DESC LIMIT ? OFFSET ?"
+	if err := db.Raw(sql, append(args, pageSize, offset)...).Scan(&entries).Error; err != nil {
+		slog.Error("Failed to query feed", "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+	decodeJSONColumns(entries, jsonColumns...)
+	utcTimestamps(entries)
+
+	resp := gin.H{
+		"page":     page,
+		"pageSize": pageSize,
+		"total":    total,
+		"entries":  entries,
+	}
+	if appliedRange != nil {
+		resp["defaultRange"] = appliedRange
+	}
+	respondJSON(c, http.StatusOK, resp)
+}
+
+// sharedColumnNames lists the columns every alerts table has through the embedded Alert
+func sharedColumnNames() []string {
+	var names []string
+	for _, col := range structColumns(reflect.TypeOf(Alert{})) {
+		names = append(names, col.Key)
+	}
+	return names
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxFilterCacheEntries bounds the filter cache; new results are not cached while it is full
+const maxFilterCacheEntries = 1000
+
+// filterCacheTTL is how long FILTER_CACHE_TTL keeps aggregate reads fresh (0 disables caching)
+var filterCacheTTL time.Duration
+
+// filterCacheEntry is a cached aggregate response and the module whose inserts invalidate
+// it; "" means any module's
+type filterCacheEntry struct {
+	resp     gin.H
+	module   string
+	cachedAt time.Time
+}
+
+var (
+	filterCacheMu sync.Mutex
+	filterCache   = make(map[string]filterCacheEntry)
+
+	filterCacheHits   atomic.Int64
+	filterCacheMisses atomic.Int64
+)
+
+// cachedFilterRead answers the request from the filter cache when it has a fresh response
+// for the same URL, counting the hit or miss. It reports whether it responded.
+func cachedFilterRead(c *gin.Context) bool {
+	if filterCacheTTL <= 0 {
+		return false
+	}
+	filterCacheMu.Lock()
+	entry, ok := filterCache[readCacheKey(c)]
+	filterCacheMu.Unlock()
+	if !ok || time.Since(entry.cachedAt) > filterCacheTTL {
+		filterCacheMisses.Add(1)
+		return false
+	}
+	filterCacheHits.Add(1)
+	respondJSON(c, http.StatusOK, entry.resp)
+	return true
+}
+
+// cacheFilterRead caches resp for this request until FILTER_CACHE_TTL passes or an alert
+// of module is stored; module "" is for reads spanning every module
+func cacheFilterRead(c *gin.Context, module string, resp gin.H) {
+	if filterCacheTTL <= 0 {
+		return
+	}
+	now := time.Now()
+	filterCacheMu.Lock()
+	defer filterCacheMu.Unlock()
+	if len(filterCache) >= maxFilterCacheEntries {
+		for key, entry := range filterCache {
+			if now.Sub(entry.cachedAt) > filterCacheTTL {
+				delete(filterCache, key)
+			}
+		}
+	}
+	key := readCacheKey(c)
+	if _, ok := filterCache[key]; !ok && len(filterCache) >= maxFilterCacheEntries {
+		return
+	}
+	filterCache[key] = filterCacheEntry{resp: resp, module: module, cachedAt: now}
+}
+
+// invalidateFilterCache drops the cached reads an alert stored or resolved in module changes
+func invalidateFilterCache(module string) {
+	if filterCacheTTL <= 0 {
+		return
+	}
+	filterCacheMu.Lock()
+	defer filterCacheMu.Unlock()
+	for key, entry := range filterCache {
+		if entry.module == "" || entry.module == module {
+			delete(filterCache, key)
+		}
+	}
+}
+
+// getFilterCacheStats godoc
+// @Summary Get filter cache statistics
+// @Description Reports how often the aggregate reads behind dashboard filters (by-event counts, alerting hosts and the dedup report) were answered from the FILTER_CACHE_TTL cache since startup. Entries are dropped when they expire or when an alert of their module is stored or resolved.
+// @Tags stats
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /stats/cache [get]
+func getFilterCacheStats(c *gin.Context) {
+	hits, misses := filterCacheHits.Load(), filterCacheMisses.Load()
+	hitRate := 0.0
+	if hits+misses > 0 {
+		hitRate = float64(hits) / float64(hits+misses)
+	}
+	filterCacheMu.Lock()
+	entries := len(filterCache)
+	filterCacheMu.Unlock()
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"enabled": filterCacheTTL > 0,
+		"ttl":     filterCacheTTL.String(),
+		"entries": entries,
+		"hits":    hits,
+		"misses":  misses,
+		"hitRate": hitRate,
+	})
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// labelKeyPattern restricts label filter keys to plain JSON path identifiers
+var labelKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// applyAlertFilters narrows query by the from, to, alert_type, host_ip, owner_team, environment, role, host_owner,
+// q and label.<key> query parameters.
+// alert_type is folded like it is at ingest, so alert_type=CRITICAL matches "critical" rows.
+// When neither from nor to is given it applies DEFAULT_RANGE and describes it in the returned map.
+func applyAlertFilters(c *gin.Context, query *gorm.DB) (*gorm.DB, gin.H) {
+	from := c.Query("from")
+	to := c.Query("to")
+
+	// Without an explicit range, only show recent alerts
+	var appliedRange gin.H
+	if from == "" && to == "" && defaultRange > 0 {
+		since := time.Now().Add(-defaultRange)
+		query = query.Where("timestamp >= ?", since)
+		appliedRange = gin.H{
+			"duration": viper.GetString("DEFAULT_RANGE"),
+			"from":     since.UTC(),
+		}
+	}
+	if from != "" {
+		if t, err := time.Parse("2006-01-02", from); err == nil {
+			query = query.Where("timestamp >= ?", t)
+		} else {
+			slog.Warn("Invalid 'from' date format", "from", from, "component", "monitor-web")
+		}
+	}
+	if to != "" {
+		if t, err := time.Parse("2006-01-02", to); err == nil {
+			query = query.Where("timestamp <= ?", t)
+		} else {
+			slog.Warn("Invalid 'to' date format", "to", to, "component", "monitor-web")
+		}
+	}
+	return applyFieldFilters(c, query), appliedRange
+}
+
+// applyFieldFilters narrows query by every filter applyAlertFilters handles except the time range
+func applyFieldFilters(c *gin.Context, query *gorm.DB) *gorm.DB {
+	alertType := c.Query("alert_type")
+	hostIP := c.Query("host_ip")
+	owner := c.Query("owner_team")
+	search := c.Query("q")
+
+	if alertType != "" {
+		query = query.Where("alert_type = ?", normalizeAlertType(alertType))
+	}
+	if hostIP != "" {
+		query = query.Where(matchHost(hostIP))
+	}
+	if owner != "" {
+		query = query.Where("owner_team = ?", owner)
+	}
+	for _, param := range []string{"environment", "role", "host_owner"} {
+		if value := c.Query(param); value != "" {
+			query = query.Where(param+" = ?", value)
+		}
+	}
+	if search != "" {
+		query = applyDetailsSearch(query, search)
+	}
+	for param, values := range c.Request.URL.Query() {
+		key, ok := strings.CutPrefix(param, "label.")
+		if !ok || len(values) == 0 {
+			continue
+		}
+		if !labelKeyPattern.MatchString(key) {
+			slog.Warn("Invalid label filter key", "key", key, "component", "monitor-web")
+			continue
+		}
+		query = query.Where(datatypes.JSONQuery("labels").Equals(values[0], key))
+	}
+	return query
+}
+
+// matchHost is the condition matching alerts for hostIP, as primary host or any of host_ips
+func matchHost(hostIP string) *gorm.DB {
+	return db.Where("host_ip = ?", hostIP).Or(datatypes.JSONArrayQuery("host_ips").Contains(hostIP))
+}
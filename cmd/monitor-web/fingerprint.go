@@ -0,0 +1,73 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// fingerprintFields are the FINGERPRINT_FIELDS columns hashed into Alert.Fingerprint, in order
+var fingerprintFields []string
+
+// initFingerprint parses FINGERPRINT_FIELDS, a comma-separated list of identity fields
+func initFingerprint() error {
+	known := identityFields(&AlertEvent{})
+	fingerprintFields = nil
+	for _, field := range splitList(viper.GetString("FINGERPRINT_FIELDS")) {
+		if _, ok := known[field]; !ok {
+			names := make([]string, 0, len(known))
+			for name := range known {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("invalid MONITOR_WEB_FINGERPRINT_FIELDS entry %q: want one of %s", field, strings.Join(names, ", "))
+		}
+		fingerprintFields = append(fingerprintFields, field)
+	}
+	if len(fingerprintFields) == 0 {
+		return fmt.Errorf("MONITOR_WEB_FINGERPRINT_FIELDS must name at least one field")
+	}
+	return nil
+}
+
+// alertFingerprint returns the sha256 hex of a's FINGERPRINT_FIELDS values joined by NUL,
+// the same digest backfillFingerprints computes in SQL
+func alertFingerprint(a *Alert) string {
+	columns := map[string]string{
+		"module":       a.Module,
+		"service_name": a.ServiceName,
+		"event_name":   a.EventName,
+		"host_ip":      a.HostIP,
+		"alert_type":   a.AlertType,
+		"cluster_name": a.ClusterName,
+		"hostname":     a.Hostname,
+	}
+	values := make([]string, len(fingerprintFields))
+	for i, field := range fingerprintFields {
+		values[i] = columns[field]
+	}
+	sum := sha256.Sum256([]byte(strings.Join(values, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// backfillFingerprints fills in the fingerprint of rows stored before the column existed,
+// so resolving still finds alerts that were open at upgrade time
+func backfillFingerprints(models ...interface{}) error {
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return fmt.Errorf("failed to parse model: %w", err)
+		}
+		sql := fmt.Sprintf("UPDATE %s SET fingerprint = SHA2(CONCAT_WS(CHAR(0), %s), 256) WHERE fingerprint = ''",
+			stmt.Quote(stmt.Schema.Table), strings.Join(fingerprintFields, ", "))
+		if err := db.Exec(sql).Error; err != nil {
+			return fmt.Errorf("failed to backfill fingerprints of %s: %w", stmt.Schema.Table, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import "testing"
+
+// fingerprintAlert is an alert with every identity field set
+func fingerprintAlert() *Alert {
+	return &Alert{
+		Module:      "redis",
+		ServiceName: "cache",
+		EventName:   "big_keys",
+		HostIP:      "10.0.0.1",
+		AlertType:   "warning",
+		ClusterName: "main",
+		Hostname:    "cache-1",
+	}
+}
+
+func TestAlertFingerprint(t *testing.T) {
+	loadTestConfig(t, map[string]string{"FINGERPRINT_FIELDS": "module,service_name,event_name,host_ip,cluster_name"})
+	base := alertFingerprint(fingerprintAlert())
+	if got := alertFingerprint(fingerprintAlert()); got != base {
+		t.Fatalf("identical alerts fingerprint to %s and %s", base, got)
+	}
+
+	tests := []struct {
+		field   string
+		mutate  func(a *Alert)
+		changes bool
+	}{
+		{"module", func(a *Alert) { a.Module = "mysql" }, true},
+		{"service_name", func(a *Alert) { a.ServiceName = "sessions" }, true},
+		{"event_name", func(a *Alert) { a.EventName = "slow_log" }, true},
+		{"host_ip", func(a *Alert) { a.HostIP = "10.0.0.2" }, true},
+		{"cluster_name", func(a *Alert) { a.ClusterName = "standby" }, true},
+		// Fields outside FINGERPRINT_FIELDS leave the fingerprint alone
+		{"alert_type", func(a *Alert) { a.AlertType = "critical" }, false},
+		{"hostname", func(a *Alert) { a.Hostname = "cache-2" }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			a := fingerprintAlert()
+			tt.mutate(a)
+			if changed := alertFingerprint(a) != base; changed != tt.changes {
+				t.Errorf("changing %s changed the fingerprint = %v, want %v", tt.field, changed, tt.changes)
+			}
+		})
+	}
+}
+
+func TestAlertFingerprintSeparatesFields(t *testing.T) {
+	loadTestConfig(t, map[string]string{"FINGERPRINT_FIELDS": "service_name,event_name"})
+	a, b := fingerprintAlert(), fingerprintAlert()
+	a.ServiceName, a.EventName = "cache", "big_keys"
+	b.ServiceName, b.EventName = "cachebig", "_keys"
+	if alertFingerprint(a) == alertFingerprint(b) {
+		t.Error("values that only differ in where one field ends share a fingerprint")
+	}
+}
+
+func TestInitFingerprintRejectsUnknownField(t *testing.T) {
+	loadTestConfig(t, nil)
+	t.Setenv("MONITOR_WEB_FINGERPRINT_FIELDS", "module,severity")
+	if err := initFingerprint(); err == nil {
+		t.Error("initFingerprint accepted an unknown field")
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxForecastDays bounds how far ahead a forecast may project
+const maxForecastDays = 366
+
+// dayCount is the number of alerts on one day; projected counts are fractional
+type dayCount struct {
+	Day   string  `json:"day"`
+	Count float64 `json:"count"`
+}
+
+// getAlertForecast godoc
+// @Summary Project a module's daily alert volume
+// @Description Counts the module's alerts per day over the filtered range and fits a straight line to the daily counts by ordinary least squares, then extends it ahead days past the last day. The historical series runs from the first day with alerts to the to date (today without one), with quiet days counted as 0; projected counts are never negative. This is a naive trend for capacity planning, not a seasonal model.
+// @Tags alerts
+// @Produce json
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param from query string false "Start date (YYYY-MM-DD); defaults to now minus DEFAULT_RANGE when from and to are omitted"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param ahead query string false "How far to project, in whole days (e.g., 7d, 30d), at most 366d" default(7d)
+// @Param alert_type query string false "Alert type filter"
+// @Param host_ip query string false "Host IP filter (matches host_ip or any of host_ips)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/forecast [get]
+func getAlertForecast(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+
+	aheadParam := c.DefaultQuery("ahead", "7d")
+	ahead, err := parseDuration(aheadParam)
+	days := int(ahead / (24 * time.Hour))
+	if err != nil || ahead%(24*time.Hour) != 0 || days < 1 || days > maxForecastDays {
+		slog.Warn("Invalid forecast horizon", "ahead", aheadParam, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid ahead"})
+		return
+	}
+
+	var rows []dayCount
+	query, appliedRange := applyAlertFilters(c, db.Table(tableName).
+		Select(chartBuckets["day"]+" AS day, COUNT(*) AS count").
+		Group("day").
+		Order("day"))
+	if err := query.Scan(&rows).Error; err != nil {
+		slog.Error("Failed to count daily alerts", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+
+	end := time.Now()
+	if t, err := time.Parse("2006-01-02", c.Query("to")); err == nil {
+		end = t
+	}
+	historical := fillDays(rows, end.Format("2006-01-02"))
+	counts := make([]float64, len(historical))
+	for i, d := range historical {
+		counts[i] = d.Count
+	}
+	intercept, slope := linearTrend(counts)
+
+	projected := []dayCount{}
+	if len(historical) > 0 {
+		last, _ := time.Parse("2006-01-02", historical[len(historical)-1].Day)
+		for i := 1; i <= days; i++ {
+			x := float64(len(historical) - 1 + i)
+			projected = append(projected, dayCount{
+				Day:   last.AddDate(0, 0, i).Format("2006-01-02"),
+				Count: math.Max(0, intercept+slope*x),
+			})
+		}
+	}
+
+	resp := gin.H{
+		"module":      module,
+		"method":      "linear",
+		"ahead":       aheadParam,
+		"slopePerDay": slope,
+		"historical":  historical,
+		"projected":   projected,
+	}
+	if appliedRange != nil {
+		resp["defaultRange"] = appliedRange
+	}
+	respondJSON(c, http.StatusOK, resp)
+}
+
+// fillDays returns rows, which are ordered by day, with every missing day from the first
+// row's day through end (YYYY-MM-DD) added as a zero count
+func fillDays(rows []dayCount, end string) []dayCount {
+	if len(rows) == 0 {
+		return []dayCount{}
+	}
+	byDay := make(map[string]float64, len(rows))
+	for _, r := range rows {
+		byDay[r.Day] = r.Count
+	}
+	if last := rows[len(rows)-1].Day; last > end {
+		end = last
+	}
+	day, err := time.Parse("2006-01-02", rows[0].Day)
+	if err != nil {
+		return rows
+	}
+	filled := []dayCount{}
+	for ; day.Format("2006-01-02") <= end; day = day.AddDate(0, 0, 1) {
+		d := day.Format("2006-01-02")
+		filled = append(filled, dayCount{Day: d, Count: byDay[d]})
+	}
+	return filled
+}
+
+// linearTrend fits count = intercept + slope*x by ordinary least squares, where x is the
+// index of each day in counts. A single day yields a flat line through it.
+func linearTrend(counts []float64) (intercept, slope float64) {
+	n := float64(len(counts))
+	if n == 0 {
+		return 0, 0
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range counts {
+		x := float64(i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	if denom := n*sumXX - sumX*sumX; denom != 0 {
+		slope = (n*sumXY - sumX*sumY) / denom
+	}
+	intercept = (sumY - slope*sumX) / n
+	return intercept, slope
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// grafanaService is the service_name of Grafana alerts without a service label
+const grafanaService = "grafana"
+
+// grafanaWebhook is Grafana's alerting webhook payload. Unified alerting sends alerts[];
+// legacy dashboard alerting sends a single rule with state and evalMatches instead.
+type grafanaWebhook struct {
+	Status string         `json:"status"`
+	Alerts []grafanaAlert `json:"alerts"`
+
+	// Legacy alerting
+	RuleName    string             `json:"ruleName"`
+	State       string             `json:"state"`
+	Message     string             `json:"message"`
+	Tags        map[string]string  `json:"tags"`
+	EvalMatches []grafanaEvalMatch `json:"evalMatches"`
+}
+
+// grafanaAlert is one unified-alerting alert instance
+type grafanaAlert struct {
+	Status       string             `json:"status"`
+	Labels       map[string]string  `json:"labels"`
+	Annotations  map[string]string  `json:"annotations"`
+	StartsAt     time.Time          `json:"startsAt"`
+	Values       map[string]float64 `json:"values"`
+	GeneratorURL string             `json:"generatorURL"`
+}
+
+// grafanaEvalMatch is one series that tripped a legacy alert rule
+type grafanaEvalMatch struct {
+	Metric string            `json:"metric"`
+	Value  float64           `json:"value"`
+	Tags   map[string]string `json:"tags"`
+}
+
+// grafanaResult is the outcome of one Grafana alert
+type grafanaResult struct {
+	EventName string `json:"event_name"`
+	Code      int    `json:"code"`
+	Result    gin.H  `json:"result"`
+}
+
+// receiveGrafanaAlerts godoc
+// @Summary Receive Grafana alerting webhooks
+// @Description Accepts Grafana's alerting webhook (unified alerts[] or legacy evalMatches), maps each alert to an AlertEvent and stores or resolves it like POST /alerts. The module comes from the GRAFANA_MODULE_LABEL label (falling back to GRAFANA_DEFAULT_MODULE), alert_type from GRAFANA_SEVERITY_LABEL, event_name from alertname, and host_ip from host_ip or instance. The response lists each alert's outcome; it is a 500, so Grafana retries, if any alert failed to store.
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param payload body grafanaWebhook true "Grafana webhook payload"
+// @Param X-Ingest-Key header string false "Shared secret for the alerts' modules, required when INGEST_KEY or INGEST_KEYS covers them"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]interface{}
+// @Router /alerts/grafana [post]
+func receiveGrafanaAlerts(c *gin.Context) {
+	var payload grafanaWebhook
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		slog.Warn("Failed to parse Grafana webhook", "error", err, "client_ip", c.ClientIP(), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+		return
+	}
+
+	events := grafanaEvents(payload)
+	code := http.StatusOK
+	results := make([]grafanaResult, 0, len(events))
+	for i := range events {
+		eventCode, resp := processEvent(c, &events[i])
+		results = append(results, grafanaResult{EventName: events[i].EventName, Code: eventCode, Result: resp})
+		if eventCode >= http.StatusInternalServerError {
+			code = http.StatusInternalServerError
+		}
+	}
+	slog.Info("Received Grafana alerts", "alerts", len(events), "client_ip", c.ClientIP(), "component", "monitor-web")
+	respondJSON(c, code, gin.H{"results": results})
+}
+
+// grafanaEvents maps a webhook payload to alert events. Legacy no_data, paused and pending
+// notifications carry no alert state change and map to nothing.
+func grafanaEvents(payload grafanaWebhook) []AlertEvent {
+	if len(payload.Alerts) == 0 && payload.RuleName != "" {
+		var state string
+		switch payload.State {
+		case "alerting":
+			state = statusFiring
+		case "ok":
+			state = statusResolved
+		default:
+			return nil
+		}
+		var lines []string
+		if payload.Message != "" {
+			lines = append(lines, payload.Message)
+		}
+		for _, m := range payload.EvalMatches {
+			lines = append(lines, fmt.Sprintf("%s=%g", m.Metric, m.Value))
+		}
+		labels := payload.Tags
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels["alertname"] = payload.RuleName
+		return []AlertEvent{grafanaEvent(labels, strings.Join(lines, "\n"), state, time.Now())}
+	}
+
+	events := make([]AlertEvent, 0, len(payload.Alerts))
+	for _, a := range payload.Alerts {
+		state := statusFiring
+		if a.Status == statusResolved {
+			state = statusResolved
+		}
+		var lines []string
+		for _, key := range []string{"summary", "description"} {
+			if text := a.Annotations[key]; text != "" {
+				lines = append(lines, text)
+			}
+		}
+		if len(a.Values) > 0 {
+			refs := make([]string, 0, len(a.Values))
+			for ref := range a.Values {
+				refs = append(refs, ref)
+			}
+			sort.Strings(refs)
+			values := make([]string, len(refs))
+			for i, ref := range refs {
+				values[i] = fmt.Sprintf("%s=%g", ref, a.Values[ref])
+			}
+			lines = append(lines, "values: "+strings.Join(values, ", "))
+		}
+		if a.GeneratorURL != "" {
+			lines = append(lines, a.GeneratorURL)
+		}
+		startsAt := a.StartsAt
+		if startsAt.IsZero() {
+			startsAt = time.Now()
+		}
+		events = append(events, grafanaEvent(a.Labels, strings.Join(lines, "\n"), state, startsAt))
+	}
+	return events
+}
+
+// grafanaEvent builds the alert event for one Grafana alert from its labels
+func grafanaEvent(labels map[string]string, details, state string, at time.Time) AlertEvent {
+	label := func(keys ...string) string {
+		for _, key := range keys {
+			if v := labels[key]; v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	module := label(viper.GetString("GRAFANA_MODULE_LABEL"))
+	if module == "" {
+		module = viper.GetString("GRAFANA_DEFAULT_MODULE")
+	}
+	service := label("service_name", "service", "job")
+	if service == "" {
+		service = grafanaService
+	}
+	hostIP := label("host_ip")
+	if hostIP == "" {
+		// instance is usually host:port
+		hostIP = label("instance")
+		if host, _, err := net.SplitHostPort(hostIP); err == nil {
+			hostIP = host
+		}
+	}
+
+	return AlertEvent{AlertCommon: AlertCommon{
+		Timestamp:   at,
+		Module:      module,
+		ServiceName: service,
+		EventName:   label("alertname"),
+		Details:     details,
+		HostIP:      hostIP,
+		AlertType:   label(viper.GetString("GRAFANA_SEVERITY_LABEL")),
+		ClusterName: label("cluster"),
+		Hostname:    label("hostname"),
+		State:       state,
+		Labels:      labels,
+	}}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"monitor-web/alertpb"
+)
+
+// grpcIngestKeyMetadata carries the ingest key on gRPC calls, like X-Ingest-Key over HTTP
+const grpcIngestKeyMetadata = "x-ingest-key"
+
+// alertIngestServer serves alertpb.AlertIngest through the same ingestEvent path as POST /api/alerts
+type alertIngestServer struct {
+	alertpb.UnimplementedAlertIngestServer
+}
+
+// newGRPCServer returns the gRPC server for GRPC_PORT, rejecting calls from outside
+// INGEST_ALLOWED_CIDRS like requireIngestSource does for HTTP
+func newGRPCServer() *grpc.Server {
+	srv := grpc.NewServer(grpc.UnaryInterceptor(grpcRequireIngestSource))
+	alertpb.RegisterAlertIngestServer(srv, alertIngestServer{})
+	return srv
+}
+
+// listenGRPC opens the GRPC_PORT listener, or returns nil when GRPC_PORT is unset
+func listenGRPC() (net.Listener, error) {
+	port := viper.GetString("GRPC_PORT")
+	if port == "" {
+		return nil, nil
+	}
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on MONITOR_WEB_GRPC_PORT %q: %w", port, err)
+	}
+	return lis, nil
+}
+
+// grpcRequireIngestSource rejects calls whose peer address is outside INGEST_ALLOWED_CIDRS
+func grpcRequireIngestSource(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	clientIP, _ := grpcCaller(ctx)
+	if !ingestSourceAllowed(clientIP) {
+		slog.Warn("Rejected ingest from disallowed source", "client_ip", clientIP, "path", info.FullMethod, "component", "monitor-web")
+		return nil, status.Error(codes.PermissionDenied, "Source IP not allowed")
+	}
+	return handler(ctx, req)
+}
+
+// grpcCaller returns the peer IP of a call and the ingest key it presented
+func grpcCaller(ctx context.Context) (clientIP, ingestKey string) {
+	if p, ok := peer.FromContext(ctx); ok {
+		clientIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(clientIP); err == nil {
+			clientIP = host
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(grpcIngestKeyMetadata); len(keys) > 0 {
+			ingestKey = keys[0]
+		}
+	}
+	return clientIP, ingestKey
+}
+
+// IngestAlert stores or resolves one event, returning a failed event as a gRPC error
+func (alertIngestServer) IngestAlert(ctx context.Context, msg *alertpb.AlertEvent) (*alertpb.IngestResult, error) {
+	clientIP, key := grpcCaller(ctx)
+	event := eventFromProto(msg)
+	result := ingestResult(ingestEvent(&event, clientIP, key))
+	if codes.Code(result.Code) != codes.OK {
+		return nil, status.Error(codes.Code(result.Code), result.Error)
+	}
+	return result, nil
+}
+
+// IngestAlerts stores or resolves each event in turn; failed events are reported in their
+// result rather than failing the call
+func (alertIngestServer) IngestAlerts(ctx context.Context, req *alertpb.IngestAlertsRequest) (*alertpb.IngestAlertsResponse, error) {
+	clientIP, key := grpcCaller(ctx)
+	results := make([]*alertpb.IngestResult, 0, len(req.GetEvents()))
+	for _, msg := range req.GetEvents() {
+		event := eventFromProto(msg)
+		results = append(results, ingestResult(ingestEvent(&event, clientIP, key)))
+	}
+	slog.Info("Received gRPC alerts", "alerts", len(results), "client_ip", clientIP, "component", "monitor-web")
+	return &alertpb.IngestAlertsResponse{Results: results}, nil
+}
+
+// eventFromProto converts a gRPC alert event into the AlertEvent the HTTP API binds
+func eventFromProto(msg *alertpb.AlertEvent) AlertEvent {
+	event := AlertEvent{
+		AlertCommon: AlertCommon{
+			Module:        msg.GetModule(),
+			ServiceName:   msg.GetServiceName(),
+			EventName:     msg.GetEventName(),
+			Details:       msg.GetDetails(),
+			HostIP:        msg.GetHostIp(),
+			HostIPs:       msg.GetHostIps(),
+			AlertType:     msg.GetAlertType(),
+			ClusterName:   msg.GetClusterName(),
+			Hostname:      msg.GetHostname(),
+			State:         msg.GetState(),
+			Labels:        msg.GetLabels(),
+			FanOut:        msg.GetFanOut(),
+			AttachmentURL: msg.GetAttachmentUrl(),
+		},
+		AlertModuleData: AlertModuleData{
+			FailedNodes:      msg.FailedNodes,
+			DeadlocksInc:     msg.DeadlocksIncrement,
+			SlowQueriesInc:   msg.SlowQueriesIncrement,
+			CPUUsage:         msg.CpuUsage,
+			MemRemaining:     msg.MemRemaining,
+			DiskUsage:        msg.DiskUsage,
+			AddedUsers:       msg.AddedUsers,
+			RemovedUsers:     msg.RemovedUsers,
+			AddedProcesses:   msg.AddedProcesses,
+			RemovedProcesses: msg.RemovedProcesses,
+		},
+	}
+	if msg.GetTimestamp() != nil {
+		event.Timestamp = msg.GetTimestamp().AsTime()
+	}
+	if len(msg.GetAttachment()) > 0 {
+		event.Attachment = base64.StdEncoding.EncodeToString(msg.GetAttachment())
+	}
+	if msg.BigKeysCount != nil {
+		n := int(*msg.BigKeysCount)
+		event.BigKeysCount = &n
+	}
+	if msg.Connections != nil {
+		n := int(*msg.Connections)
+		event.Connections = &n
+	}
+	return event
+}
+
+// ingestResult converts ingestEvent's HTTP status and body into a gRPC result
+func ingestResult(code int, resp gin.H) *alertpb.IngestResult {
+	result := &alertpb.IngestResult{Code: uint32(grpcCode(code))}
+	result.Status, _ = resp["status"].(string)
+	result.Id, _ = resp["id"].(uint64)
+	result.Ids, _ = resp["ids"].([]uint64)
+	result.IncidentId, _ = resp["incidentId"].(string)
+	result.Suppressed, _ = resp["suppressed"].(bool)
+	result.Error, _ = resp["error"].(string)
+	result.IgnoredFields, _ = resp["ignoredFields"].([]string)
+	return result
+}
+
+// grpcCode maps an ingest HTTP status to its gRPC status code
+func grpcCode(code int) codes.Code {
+	switch {
+	case code < http.StatusBadRequest:
+		return codes.OK
+	case code == http.StatusForbidden:
+		return codes.PermissionDenied
+	case code == http.StatusConflict:
+		return codes.AlreadyExists
+	case code < http.StatusInternalServerError:
+		return codes.InvalidArgument
+	case code == http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// heatmapDays labels the heatmap rows; WEEKDAY() numbers them from Monday
+var heatmapDays = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// heatmapCell is the number of alerts in one weekday and hour of day
+type heatmapCell struct {
+	Weekday int
+	Hour    int
+	Count   int64
+}
+
+// getAlertHeatmap godoc
+// @Summary Get alert counts by day of week and hour of day
+// @Description Returns a 7×24 matrix of alert counts for a heatmap of when alerts cluster: matrix[d][h] counts alerts on days[d] (Monday first) during hour h (0-23 UTC). Honors the same filters as the alerts listing.
+// @Tags alerts
+// @Produce json
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param from query string false "Start date (YYYY-MM-DD); defaults to now minus DEFAULT_RANGE when from and to are omitted"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param alert_type query string false "Alert type filter"
+// @Param host_ip query string false "Host IP filter (matches host_ip or any of host_ips)"
+// @Param owner_team query string false "Owning team filter (from SERVICE_OWNERS)"
+// @Param environment query string false "Host environment filter (from CMDB_SOURCE)"
+// @Param role query string false "Host role filter (from CMDB_SOURCE)"
+// @Param host_owner query string false "Host owner filter (from CMDB_SOURCE)"
+// @Param q query string false "Search text in details (substring match, or full-text match with ENABLE_FULLTEXT)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/heatmap [get]
+func getAlertHeatmap(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+
+	query := db.Table(tableName).
+		Select("WEEKDAY(" + utcTimestampSQL + ") AS weekday, HOUR(" + utcTimestampSQL + ") AS hour, COUNT(*) AS count").
+		Group("weekday, hour")
+	query, appliedRange := applyAlertFilters(c, query)
+
+	var cells []heatmapCell
+	if err := query.Scan(&cells).Error; err != nil {
+		slog.Error("Failed to aggregate alert heatmap", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+
+	matrix := make([][]int64, len(heatmapDays))
+	for d := range matrix {
+		matrix[d] = make([]int64, 24)
+	}
+	var total int64
+	for _, cell := range cells {
+		if cell.Weekday < 0 || cell.Weekday >= len(heatmapDays) || cell.Hour < 0 || cell.Hour >= 24 {
+			continue
+		}
+		matrix[cell.Weekday][cell.Hour] += cell.Count
+		total += cell.Count
+	}
+
+	resp := gin.H{
+		"module": module,
+		"days":   heatmapDays,
+		"matrix": matrix,
+		"total":  total,
+	}
+	if appliedRange != nil {
+		resp["defaultRange"] = appliedRange
+	}
+	respondJSON(c, http.StatusOK, resp)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetAlertHeatmapBucketsInUTC(t *testing.T) {
+	loadTestConfig(t, nil)
+	mock := mockDB(t)
+	mock.ExpectQuery("SELECT WEEKDAY\\(CONVERT_TZ\\(timestamp, @@session.time_zone, '\\+00:00'\\)\\) AS weekday, HOUR\\(CONVERT_TZ\\(timestamp, @@session.time_zone, '\\+00:00'\\)\\) AS hour").
+		WillReturnRows(sqlmock.NewRows([]string{"weekday", "hour", "count"}).AddRow(2, 23, 4))
+
+	r := gin.New()
+	r.GET("/api/alerts/:module/heatmap", getAlertHeatmap)
+	w := serveRequest(r, httptest.NewRequest(http.MethodGet, "/api/alerts/redis/heatmap", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Matrix [][]int64 `json:"matrix"`
+		Total  int64     `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Total != 4 || resp.Matrix[2][23] != 4 {
+		t.Errorf("total = %d, matrix[Wed][23] = %d; want 4, 4", resp.Total, resp.Matrix[2][23])
+	}
+}
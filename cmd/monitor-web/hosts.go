@@ -0,0 +1,116 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// hostSeverityCount is the number of open alerts of one severity on one host
+type hostSeverityCount struct {
+	HostIP   string
+	Severity string
+	Count    int64
+}
+
+// alertingHost summarizes the open alerts of one host
+type alertingHost struct {
+	HostIP        string `json:"host_ip"`
+	Count         int64  `json:"count"`
+	WorstSeverity string `json:"worst_severity"`
+}
+
+// getAlertingHosts godoc
+// @Summary List the hosts with open alerts
+// @Description For a fleet heat-map: the distinct host_ip values with open (unresolved) alerts in the trailing window, each with its open alert count and worst severity (alert_type, ranked by SEVERITY_ORDER), worst first. Without module, counts are merged across every module table. With FILTER_CACHE_TTL set, responses are cached for that long or until an alert of the module (of any module, without module) is stored or resolved.
+// @Tags hosts
+// @Produce json
+// @Param module query string false "Module name (e.g., redis, mysql, host, system, general); all modules when omitted"
+// @Param window query string false "Trailing window (e.g., 30m, 1h, 1d)" default(1h)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/alerting [get]
+func getAlertingHosts(c *gin.Context) {
+	modules := storedModules()
+	module := canonicalModule(c.Query("module"))
+	if module != "" {
+		if _, ok := moduleTable(module); !ok {
+			slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+			return
+		}
+		modules = []string{module}
+	}
+	windowParam := c.DefaultQuery("window", "1h")
+	window, err := parseDuration(windowParam)
+	if err != nil || window <= 0 {
+		slog.Warn("Invalid alerting hosts window", "window", windowParam, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid window"})
+		return
+	}
+	if cachedFilterRead(c) {
+		return
+	}
+
+	since := time.Now().Add(-window)
+	var parts []string
+	var args []interface{}
+	for _, m := range modules {
+		table, _ := moduleTable(m)
+		parts = append(parts, "(?)")
+		args = append(args, db.Table(table).
+			Select("host_ip, alert_type AS severity, COUNT(*) AS count").
+			Where("status <> ? AND timestamp >= ? AND host_ip <> ''", statusResolved, since).
+			Group("host_ip, alert_type"))
+	}
+	var counts []hostSeverityCount
+	sql := "SELECT host_ip, severity, SUM(count) AS count FROM (" + strings.Join(parts, " UNION ALL ") + ") AS open_alerts GROUP BY host_ip, severity"
+	if err := db.Raw(sql, args...).Scan(&counts).Error; err != nil {
+		slog.Error("Failed to query alerting hosts", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+
+	byHost := make(map[string]*alertingHost)
+	for _, hc := range counts {
+		h, ok := byHost[hc.HostIP]
+		if !ok {
+			h = &alertingHost{HostIP: hc.HostIP, WorstSeverity: hc.Severity}
+			byHost[hc.HostIP] = h
+		}
+		h.Count += hc.Count
+		if severityRank(hc.Severity) < severityRank(h.WorstSeverity) {
+			h.WorstSeverity = hc.Severity
+		}
+	}
+	hosts := make([]alertingHost, 0, len(byHost))
+	for _, h := range byHost {
+		hosts = append(hosts, *h)
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		ri, rj := severityRank(hosts[i].WorstSeverity), severityRank(hosts[j].WorstSeverity)
+		if ri != rj {
+			return ri < rj
+		}
+		if hosts[i].Count != hosts[j].Count {
+			return hosts[i].Count > hosts[j].Count
+		}
+		return hosts[i].HostIP < hosts[j].HostIP
+	})
+
+	resp := gin.H{
+		"window": windowParam,
+		"since":  since.UTC(),
+		"hosts":  hosts,
+	}
+	if module != "" {
+		resp["module"] = module
+	}
+	cacheFilterRead(c, module, resp)
+	respondJSON(c, http.StatusOK, resp)
+}
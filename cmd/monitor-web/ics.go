@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	icsContentType = "text/calendar; charset=utf-8"
+	icsTimeFormat  = "20060102T150405Z"
+	icsMaxEvents   = 10000
+	// icsOpenDuration is the length given to alerts that have not been resolved yet
+	icsOpenDuration = 5 * time.Minute
+)
+
+// icsEscaper escapes TEXT property values (RFC 5545 section 3.3.11)
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// exportAlertsICS godoc
+// @Summary Export alerts as an iCalendar feed
+// @Description Emits the module's alerts matching the filters, newest first, as iCalendar VEVENTs for reviewing incidents in a calendar. Each event starts at the alert's timestamp and ends at resolved_at, or 5 minutes later while the alert is still open; its summary is the event_name and its description the details. At most 10000 alerts are exported.
+// @Tags alerts
+// @Produce text/calendar
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param from query string false "Start date (YYYY-MM-DD); defaults to now minus DEFAULT_RANGE when from and to are omitted"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param alert_type query string false "Alert type filter"
+// @Param host_ip query string false "Host IP filter (matches host_ip or any of host_ips)"
+// @Param owner_team query string false "Owning team filter (from SERVICE_OWNERS)"
+// @Param environment query string false "Host environment filter (from CMDB_SOURCE)"
+// @Param role query string false "Host role filter (from CMDB_SOURCE)"
+// @Param host_owner query string false "Host owner filter (from CMDB_SOURCE)"
+// @Param q query string false "Search text in details (substring match, or full-text match with ENABLE_FULLTEXT)"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/export.ics [get]
+func exportAlertsICS(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+
+	query, _ := applyAlertFilters(c, db.Table(tableName).Order("timestamp desc, id desc").Limit(icsMaxEvents))
+	alerts, err := findTyped[Alert](query)
+	if err != nil {
+		slog.Error("Failed to query alerts for export", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+
+	var buf bytes.Buffer
+	icsLine(&buf, "BEGIN:VCALENDAR")
+	icsLine(&buf, "VERSION:2.0")
+	icsLine(&buf, "PRODID:-//monitor-web//alerts//EN")
+	icsLine(&buf, "CALSCALE:GREGORIAN")
+	icsLine(&buf, "X-WR-CALNAME:"+icsEscaper.Replace(module+" alerts"))
+	for i := range alerts {
+		writeICSEvent(&buf, module, &alerts[i])
+	}
+	icsLine(&buf, "END:VCALENDAR")
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-alerts.ics"`, module))
+	c.Data(http.StatusOK, icsContentType, buf.Bytes())
+	slog.Info("Exported alerts", "module", module, "rows", len(alerts), "format", "ics", "client_ip", c.ClientIP(), "component", "monitor-web")
+}
+
+// writeICSEvent writes one alert as a VEVENT
+func writeICSEvent(buf *bytes.Buffer, module string, a *Alert) {
+	end := a.Timestamp.Add(icsOpenDuration)
+	if a.ResolvedAt != nil && a.ResolvedAt.After(a.Timestamp) {
+		end = *a.ResolvedAt
+	}
+	stamp := a.CreatedAt
+	if stamp.IsZero() {
+		stamp = a.Timestamp
+	}
+	icsLine(buf, "BEGIN:VEVENT")
+	icsLine(buf, fmt.Sprintf("UID:%s-%d@monitor-web", module, a.ID))
+	icsLine(buf, "DTSTAMP:"+stamp.UTC().Format(icsTimeFormat))
+	icsLine(buf, "DTSTART:"+a.Timestamp.UTC().Format(icsTimeFormat))
+	icsLine(buf, "DTEND:"+end.UTC().Format(icsTimeFormat))
+	icsLine(buf, "SUMMARY:"+icsEscaper.Replace(a.EventName))
+	icsLine(buf, "DESCRIPTION:"+icsEscaper.Replace(a.Details))
+	icsLine(buf, "CATEGORIES:"+icsEscaper.Replace(a.AlertType))
+	icsLine(buf, "X-MONITOR-WEB-STATUS:"+icsEscaper.Replace(a.Status))
+	icsLine(buf, "END:VEVENT")
+}
+
+// icsLine writes a content line terminated by CRLF, folded so no line exceeds 75 octets
+// without splitting a UTF-8 sequence
+func icsLine(buf *bytes.Buffer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards their 75 octets
+		limit = 74
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+const (
+	importBatchSize   = 500
+	maxImportLineSize = 4 << 20 // details are capped well below this by MAX_DETAILS_BYTES
+	maxImportFailures = 1000    // failures beyond this are counted but not listed
+)
+
+// importFailure records why one NDJSON line was not stored
+type importFailure struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// importRow is a validated line waiting to be inserted
+type importRow struct {
+	line   int
+	record alertRecord
+}
+
+// importSummary accumulates the outcome of an import
+type importSummary struct {
+	Lines     int             `json:"lines"`
+	Stored    int             `json:"stored"`
+	Resolved  int             `json:"resolved"`
+	Failed    int             `json:"failed"`
+	Valid     int             `json:"valid,omitempty"` // Rows a dry run found valid
+	Failures  []importFailure `json:"failures"`
+	Truncated bool            `json:"failures_truncated,omitempty"`
+}
+
+func (s *importSummary) fail(line int, msg string) {
+	s.Failed++
+	if len(s.Failures) >= maxImportFailures {
+		s.Truncated = true
+		return
+	}
+	s.Failures = append(s.Failures, importFailure{Line: line, Error: msg})
+}
+
+// importAlerts godoc
+// @Summary Import alerts from an NDJSON stream
+// @Description Streams a newline-delimited JSON body (optionally gzip-compressed) where each line is an AlertEvent, validating and inserting in batches. Returns a summary with the line numbers that failed. Lines with fan_out set are rejected. After a section marker line of a backup from GET /admin/backup.ndjson.gz, lines are restored into the marker's module table as stored, without revalidation; rows whose id already exists fail, so an interrupted restore can be rerun.
+// @Tags alerts
+// @Accept plain
+// @Produce json
+// @Param body body string true "NDJSON alert events, one per line"
+// @Param X-Ingest-Key header string false "Shared secret; lines for modules it does not unlock fail with Invalid ingest key"
+// @Success 200 {object} importSummary
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /alerts/import [post]
+func importAlerts(c *gin.Context) {
+	body, err := decompressedBody(c.Request)
+	if err != nil {
+		slog.Error("Failed to open import body", "error", err, "client_ip", c.ClientIP(), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid gzip body"})
+		return
+	}
+	defer body.Close()
+
+	summary := &importSummary{Failures: []importFailure{}}
+	batch := make([]importRow, 0, importBatchSize)
+	// section is the backup section being restored, nil while importing events
+	var section *backupMarker
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), maxImportLineSize)
+	for scanner.Scan() {
+		summary.Lines++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if bytes.HasPrefix(line, backupMarkerPrefix) {
+			section = &backupMarker{}
+			if err := json.Unmarshal(line, section); err != nil {
+				summary.fail(summary.Lines, "invalid backup marker: "+err.Error())
+			}
+			continue
+		}
+		var record alertRecord
+		if section != nil {
+			record = restoreImportRow(c, section.Module, line, summary.Lines, summary)
+		} else {
+			record = importEventLine(c, line, summary.Lines, summary)
+		}
+		if record == nil {
+			continue
+		}
+		batch = append(batch, importRow{line: summary.Lines, record: record})
+		if len(batch) == importBatchSize {
+			flushImportBatch(batch, summary)
+			batch = batch[:0]
+		}
+	}
+	flushImportBatch(batch, summary)
+	if err := scanner.Err(); err != nil {
+		// Keep what was stored so far and report where the stream broke off
+		summary.fail(summary.Lines+1, "read error: "+err.Error())
+	}
+
+	slog.Info("Imported alerts", "lines", summary.Lines, "stored", summary.Stored, "resolved", summary.Resolved, "failed", summary.Failed, "client_ip", c.ClientIP(), "component", "monitor-web")
+	respondJSON(c, http.StatusOK, summary)
+}
+
+// importEventLine decodes and stages the AlertEvent on line n, returning the record to
+// batch-insert, or nil when the event is done with or failed as stageImportEvent does
+func importEventLine(c *gin.Context, line []byte, n int, summary *importSummary) alertRecord {
+	var event AlertEvent
+	if err := json.Unmarshal(line, &event); err != nil {
+		summary.fail(n, "invalid JSON: "+err.Error())
+		return nil
+	}
+	if viper.GetBool("STRICT_SCHEMA") {
+		if unknown := unknownFields(line, reflect.TypeOf(event)); len(unknown) > 0 {
+			summary.fail(n, "Unknown fields: "+strings.Join(unknown, ", "))
+			return nil
+		}
+	}
+	if event.FanOut {
+		// Batched inserts cannot keep a fanned-out incident atomic
+		summary.fail(n, "fan_out is not supported by import")
+		return nil
+	}
+	return stageImportEvent(c, &event, n, summary)
+}
+
+// restoreImportRow decodes one backed-up row of module's table into its model, columns
+// as stored, so a restore keeps the alert's id, lifecycle and raw_alert_type and is not
+// subject to the ingest checks the row passed when it first arrived. It returns nil after
+// recording the failure in summary against line n.
+func restoreImportRow(c *gin.Context, module string, line []byte, n int, summary *importSummary) alertRecord {
+	if _, ok := moduleTable(module); !ok {
+		summary.fail(n, "Invalid module in backup marker")
+		return nil
+	}
+	if err := checkIngestKey(module, c.GetHeader(ingestKeyHeader)); err != nil {
+		summary.fail(n, err.Error())
+		return nil
+	}
+	record := newModuleModel(module)
+	unknown, err := parseRecordJSON(line, record)
+	if err != nil {
+		summary.fail(n, "invalid JSON: "+err.Error())
+		return nil
+	}
+	if len(unknown) > 0 && viper.GetBool("STRICT_SCHEMA") {
+		summary.fail(n, "Unknown fields: "+strings.Join(unknown, ", "))
+		return nil
+	}
+	return record
+}
+
+// stageImportEvent validates an imported event, resolving the matching open alert for a
+// resolved one. It returns the record to batch-insert, or nil when the event is done with
+// or failed, in which case the failure is recorded in summary against line.
+func stageImportEvent(c *gin.Context, event *AlertEvent, line int, summary *importSummary) alertRecord {
+	record := validateImportEvent(c, event, line, summary)
+	if record == nil {
+		return nil
+	}
+	if event.State == statusResolved {
+		if _, found, err := resolveAlert(record); err != nil {
+			summary.fail(line, "Failed to resolve alert")
+		} else if found {
+			summary.Resolved++
+		} else {
+			summary.fail(line, "No open alert to resolve")
+		}
+		return nil
+	}
+	return record
+}
+
+// validateImportEvent authenticates and validates an imported event, returning its
+// prepared record, or nil after recording the failure in summary against line
+func validateImportEvent(c *gin.Context, event *AlertEvent, line int, summary *importSummary) alertRecord {
+	record, err := admitEvent(event, c.ClientIP(), ingestKeyCheck(c.GetHeader(ingestKeyHeader)))
+	if err != nil {
+		summary.fail(line, err.Error())
+		return nil
+	}
+	return record
+}
+
+// decompressedBody returns the request body, transparently un-gzipping it when it is
+// declared via Content-Encoding or starts with the gzip magic bytes
+func decompressedBody(r *http.Request) (io.ReadCloser, error) {
+	br := bufio.NewReader(r.Body)
+	magic, _ := br.Peek(2)
+	if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") || bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		return gzip.NewReader(br)
+	}
+	return io.NopCloser(br), nil
+}
+
+// flushImportBatch inserts rows with one multi-row INSERT per module table, falling back
+// to row-by-row inserts for a table whose batch fails so the bad lines can be reported
+func flushImportBatch(rows []importRow, summary *importSummary) {
+	groups := make(map[reflect.Type][]importRow)
+	var order []reflect.Type
+	for _, row := range rows {
+		t := reflect.TypeOf(row.record).Elem()
+		if _, ok := groups[t]; !ok {
+			order = append(order, t)
+		}
+		groups[t] = append(groups[t], row)
+	}
+
+	for _, t := range order {
+		group := groups[t]
+		slice := reflect.MakeSlice(reflect.SliceOf(t), 0, len(group))
+		for _, row := range group {
+			slice = reflect.Append(slice, reflect.ValueOf(row.record).Elem())
+		}
+		if err := db.Create(slice.Interface()).Error; err == nil {
+			summary.Stored += len(group)
+			invalidateFilterCache(group[0].record.base().Module)
+			continue
+		}
+		for _, row := range group {
+			if err := db.Create(row.record).Error; err != nil {
+				discardAttachment(row.record)
+				_, msg := classifyDBError(err)
+				summary.fail(row.line, msg)
+				continue
+			}
+			summary.Stored++
+		}
+		invalidateFilterCache(group[0].record.base().Module)
+	}
+}
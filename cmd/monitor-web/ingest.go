@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"gorm.io/datatypes"
+)
+
+// ingestError is an event validation failure; its message is returned to the client
+type ingestError struct {
+	msg string
+}
+
+func (e *ingestError) Error() string { return e.msg }
+
+var (
+	errMissingFields = &ingestError{"Missing required fields"}
+	errInvalidState  = &ingestError{"Invalid state: must be firing or resolved"}
+)
+
+// admitEvent is the entry point every ingest path sends an event through, exactly once: it
+// normalizes event, authenticates it with authorize (given the canonical module; nil when
+// the caller authenticated the request otherwise) before any validation work or DB lookups
+// are spent on it, and prepares its record
+func admitEvent(event *AlertEvent, clientIP string, authorize func(module string) error) (alertRecord, error) {
+	normalizeEvent(event)
+	if authorize != nil {
+		if err := authorize(canonicalModule(event.Module)); err != nil {
+			return nil, err
+		}
+	}
+	return prepareRecord(event, clientIP)
+}
+
+// prepareRecord validates event, which admitEvent has normalized, and builds the
+// module-specific record to insert
+func prepareRecord(event *AlertEvent, clientIP string) (alertRecord, error) {
+	// Validate required fields
+	if event.Module == "" || event.ServiceName == "" || event.EventName == "" {
+		return nil, errMissingFields
+	}
+
+	// Normalize agent-specific module names before the module decides the table
+	if module := canonicalModule(event.Module); module != event.Module {
+		slog.Info("Applied module alias", "alias", event.Module, "module", module, "client_ip", clientIP, "component", "monitor-web")
+		event.Module = module
+	}
+
+	if foreign := foreignModuleFields(event); len(foreign) > 0 {
+		if viper.GetBool("STRICT_SCHEMA") {
+			return nil, &ingestError{fmt.Sprintf("Fields not used by module %s: %s", event.Module, strings.Join(foreign, ", "))}
+		}
+		slog.Warn("Ignoring fields of another module", "module", event.Module, "fields", foreign, "client_ip", clientIP, "component", "monitor-web")
+	}
+
+	if event.State != "" && event.State != statusFiring && event.State != statusResolved {
+		return nil, errInvalidState
+	}
+
+	// Replayed backlogs from an agent outage would otherwise land on current dashboards
+	if maxEventAge > 0 && time.Since(event.Timestamp) > maxEventAge {
+		slog.Warn("Rejected stale alert", "module", event.Module, "timestamp", event.Timestamp, "max_age", maxEventAge.String(), "client_ip", clientIP, "component", "monitor-web")
+		return nil, &ingestError{fmt.Sprintf("Stale event: timestamp is older than the maximum age of %s", maxEventAge)}
+	}
+
+	if err := validateAttachmentURL(event.AttachmentURL); err != nil {
+		return nil, err
+	}
+
+	// Strip PII before storage
+	if details, n := redact(event.Details); n > 0 {
+		event.Details = details
+		slog.Info("Redacted alert details", "module", event.Module, "redactions", n, "client_ip", clientIP, "component", "monitor-web")
+	}
+
+	// Cap pathological lists by entry count before the byte cap cuts them mid-entry
+	if dropped := capListEntries(event); len(dropped) > 0 {
+		slog.Warn("Capped oversized list fields", "module", event.Module, "dropped", dropped, "limit", viper.GetInt("MAX_LIST_ENTRIES"), "client_ip", clientIP, "component", "monitor-web")
+	}
+
+	// Cap oversized free-text fields before they reach the database
+	if truncated := truncateEventFields(event); len(truncated) > 0 {
+		slog.Warn("Truncated oversized alert fields", "module", event.Module, "fields", truncated, "limit", viper.GetInt("MAX_DETAILS_BYTES"), "client_ip", clientIP, "component", "monitor-web")
+	}
+
+	// Use the first of host_ips as the primary host when host_ip is omitted
+	if event.HostIP == "" && len(event.HostIPs) > 0 {
+		event.HostIP = event.HostIPs[0]
+	}
+
+	if err := checkClockSkew(event, clientIP); err != nil {
+		return nil, err
+	}
+
+	// Alerts from muted hosts are stored but flagged as suppressed
+	suppressed, err := isHostMuted(append([]string{event.HostIP}, event.HostIPs...))
+	if err != nil {
+		slog.Warn("Failed to check host mutes", "error", err, "client_ip", clientIP, "component", "monitor-web")
+	}
+
+	// Resolving needs no attachment, so only firing events write their blob
+	var attachmentFile, attachmentType string
+	if event.State != statusResolved {
+		if attachmentFile, attachmentType, err = storeAttachment(event); err != nil {
+			return nil, err
+		}
+	}
+	record := buildRecord(event, suppressed)
+	record.base().AttachmentFile = attachmentFile
+	record.base().AttachmentType = attachmentType
+	return record, nil
+}
+
+// buildRecord builds the module-specific record for an event prepareRecord has validated
+func buildRecord(event *AlertEvent, suppressed bool) alertRecord {
+	var labels datatypes.JSON
+	if len(event.Labels) > 0 {
+		labels, _ = json.Marshal(event.Labels) // map[string]string always marshals
+	}
+
+	// Agents that leave severity to the server get it from the module's rules; the event
+	// keeps its empty alert_type so raw_alert_type records what was actually sent
+	alertType := event.AlertType
+	if strings.TrimSpace(alertType) == "" {
+		if severity := deriveSeverity(event); severity != "" {
+			alertType = severity
+			slog.Debug("Derived alert severity", "module", event.Module, "event_name", event.EventName, "alert_type", severity, "component", "monitor-web")
+		}
+	}
+
+	// Common alert fields
+	alert := Alert{
+		Timestamp:     event.Timestamp,
+		Module:        event.Module,
+		ServiceName:   event.ServiceName,
+		EventName:     event.EventName,
+		Details:       event.Details,
+		HostIP:        event.HostIP,
+		HostIPs:       datatypes.NewJSONSlice(event.HostIPs),
+		AlertType:     normalizeAlertType(alertType),
+		RawAlertType:  event.AlertType,
+		ClusterName:   event.ClusterName,
+		Hostname:      event.Hostname,
+		OwnerTeam:     ownerTeam(event.ServiceName),
+		AttachmentURL: event.AttachmentURL,
+		Labels:        labels,
+		Suppressed:    suppressed,
+		Status:        statusFiring,
+	}
+	enrichFromCMDB(&alert)
+	alert.Fingerprint = alertFingerprint(&alert)
+	return newModuleRecord(alert, *event)
+}
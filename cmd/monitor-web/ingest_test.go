@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// testEvent is a valid redis event stamped at ts
+func testEvent(ts time.Time) *AlertEvent {
+	var event AlertEvent
+	event.Timestamp = ts
+	event.Module = "redis"
+	event.ServiceName = "cache"
+	event.EventName = "big_keys"
+	event.HostIP = "10.0.0.1"
+	return &event
+}
+
+func TestPrepareRecordMaxEventAge(t *testing.T) {
+	loadTestConfig(t, map[string]string{"MAX_EVENT_AGE": "1h"})
+	mock := mockDB(t)
+
+	t.Run("just inside", func(t *testing.T) {
+		mock.ExpectQuery("SELECT count\\(\\*\\) FROM `host_mutes`").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		record, err := prepareRecord(testEvent(time.Now().Add(-59*time.Minute)), "192.0.2.1")
+		if err != nil || record == nil {
+			t.Fatalf("prepareRecord = %v, %v; want a record", record, err)
+		}
+	})
+
+	t.Run("just outside", func(t *testing.T) {
+		_, err := prepareRecord(testEvent(time.Now().Add(-61*time.Minute)), "192.0.2.1")
+		var invalid *ingestError
+		if !errors.As(err, &invalid) {
+			t.Fatalf("prepareRecord error = %v, want an *ingestError", err)
+		}
+	})
+}
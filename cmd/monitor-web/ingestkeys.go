@@ -0,0 +1,61 @@
+package main
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ingestKeyHeader carries the shared secret an agent presents on ingest
+const ingestKeyHeader = "X-Ingest-Key"
+
+var (
+	// ingestKey is the INGEST_KEY accepted for modules without their own key
+	ingestKey string
+	// moduleIngestKeys are the per-module INGEST_KEYS, keyed by canonical module
+	moduleIngestKeys map[string]string
+)
+
+var errInvalidIngestKey = &ingestError{"Invalid ingest key"}
+
+// ingestKeyCheck returns the admitEvent authorizer for a request that presented key
+func ingestKeyCheck(presented string) func(module string) error {
+	return func(module string) error { return checkIngestKey(module, presented) }
+}
+
+// initIngestKeys loads INGEST_KEY and INGEST_KEYS, a comma-separated list of module:key
+// pairs. Entries are reported by position so a malformed one never leaks a key into logs.
+func initIngestKeys() error {
+	ingestKey = viper.GetString("INGEST_KEY")
+	moduleIngestKeys = make(map[string]string)
+	for i, entry := range splitList(viper.GetString("INGEST_KEYS")) {
+		module, key, ok := strings.Cut(entry, ":")
+		module, key = strings.TrimSpace(module), strings.TrimSpace(key)
+		if !ok || module == "" || key == "" {
+			return fmt.Errorf("invalid MONITOR_WEB_INGEST_KEYS entry %d: want module:key", i+1)
+		}
+		if _, known := moduleTable(module); !known {
+			return fmt.Errorf("invalid MONITOR_WEB_INGEST_KEYS entry: unknown module %q", module)
+		}
+		moduleIngestKeys[module] = key
+	}
+	return nil
+}
+
+// checkIngestKey verifies presented against the key for module, falling back to the
+// global key when the module has none configured; without either, module is open
+func checkIngestKey(module, presented string) error {
+	want, ok := moduleIngestKeys[module]
+	if !ok {
+		want = ingestKey
+	}
+	if want == "" {
+		return nil
+	}
+	if subtle.ConstantTimeCompare([]byte(presented), []byte(want)) != 1 {
+		return errInvalidIngestKey
+	}
+	return nil
+}
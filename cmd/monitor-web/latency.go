@@ -0,0 +1,136 @@
+package main
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// latencySamples is how many recent requests per route the percentiles are computed over
+const latencySamples = 1024
+
+// slowRequestThreshold is the SLOW_REQUEST_THRESHOLD above which requests are logged (0 disables it)
+var slowRequestThreshold time.Duration
+
+// routeLatency is a ring buffer of one route's most recent request durations
+type routeLatency struct {
+	samples [latencySamples]time.Duration
+	next    int
+	total   int64
+}
+
+func (r *routeLatency) add(d time.Duration) {
+	r.samples[r.next] = d
+	r.next = (r.next + 1) % latencySamples
+	r.total++
+}
+
+// sorted returns the buffered durations in ascending order
+func (r *routeLatency) sorted() []time.Duration {
+	n := latencySamples
+	if r.total < latencySamples {
+		n = int(r.total)
+	}
+	out := append([]time.Duration(nil), r.samples[:n]...)
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// latencyKey identifies a route by method and path pattern
+type latencyKey struct {
+	method, route string
+}
+
+var (
+	latencyMu sync.Mutex
+	// latencies holds one buffer per route; unmatched paths are not tracked so the map
+	// stays bounded by the route table
+	latencies = make(map[latencyKey]*routeLatency)
+)
+
+// trackLatency records each request's duration against its route and logs requests slower
+// than SLOW_REQUEST_THRESHOLD. It runs outside recoverJSON so panicking requests count too.
+func trackLatency(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+	elapsed := time.Since(start)
+
+	route := c.FullPath()
+	if route == "" {
+		return
+	}
+	key := latencyKey{method: c.Request.Method, route: route}
+	latencyMu.Lock()
+	r, ok := latencies[key]
+	if !ok {
+		r = &routeLatency{}
+		latencies[key] = r
+	}
+	r.add(elapsed)
+	latencyMu.Unlock()
+
+	if slowRequestThreshold > 0 && elapsed > slowRequestThreshold {
+		slog.Warn("Slow request", "method", c.Request.Method, "route", route, "status", c.Writer.Status(), "duration_ms", elapsed.Milliseconds(), "threshold", slowRequestThreshold.String(), "request_id", c.GetString(requestIDKey), "client_ip", c.ClientIP(), "component", "monitor-web")
+	}
+}
+
+// endpointLatency is the latency summary of one route
+type endpointLatency struct {
+	Method   string  `json:"method"`
+	Route    string  `json:"route"`
+	Requests int64   `json:"requests"`
+	Samples  int     `json:"samples"`
+	P50Ms    float64 `json:"p50_ms"`
+	P95Ms    float64 `json:"p95_ms"`
+	P99Ms    float64 `json:"p99_ms"`
+}
+
+// percentile returns the nearest-rank p-th percentile of ascending durations, in milliseconds
+func percentile(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return float64(sorted[i]) / float64(time.Millisecond)
+}
+
+// getLatencyStats godoc
+// @Summary Get per-endpoint request latency percentiles
+// @Description Returns p50, p95 and p99 latency in milliseconds for each route, computed over its most recent 1024 requests since startup, plus the total request count. Slower requests than SLOW_REQUEST_THRESHOLD are also logged.
+// @Tags stats
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /stats/latency [get]
+func getLatencyStats(c *gin.Context) {
+	latencyMu.Lock()
+	endpoints := make([]endpointLatency, 0, len(latencies))
+	for key, r := range latencies {
+		sorted := r.sorted()
+		endpoints = append(endpoints, endpointLatency{
+			Method:   key.method,
+			Route:    key.route,
+			Requests: r.total,
+			Samples:  len(sorted),
+			P50Ms:    percentile(sorted, 50),
+			P95Ms:    percentile(sorted, 95),
+			P99Ms:    percentile(sorted, 99),
+		})
+	}
+	latencyMu.Unlock()
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Route != endpoints[j].Route {
+			return endpoints[i].Route < endpoints[j].Route
+		}
+		return endpoints[i].Method < endpoints[j].Method
+	})
+	respondJSON(c, http.StatusOK, gin.H{"endpoints": endpoints})
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// getLatestPerHost godoc
+// @Summary Get the latest alert per host for a module
+// @Description Returns each host's most recent alert (newest timestamp, ties broken by highest id), honoring the same filters as the alerts listing. At most MAX_PAGE_SIZE hosts are returned.
+// @Tags alerts
+// @Produce json
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param alert_type query string false "Alert type filter"
+// @Param q query string false "Search text in details (substring match, or full-text match with ENABLE_FULLTEXT)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/latest-per-host [get]
+func getLatestPerHost(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+
+	ranked := db.Table(tableName).
+		Select("*, ROW_NUMBER() OVER (PARTITION BY host_ip ORDER BY timestamp DESC, id DESC) AS host_rank")
+	ranked, appliedRange := applyAlertFilters(c, ranked)
+
+	alerts := []map[string]interface{}{}
+	err := db.Table("(?) AS ranked", ranked).
+		Where("host_rank = 1").
+		Order("host_ip").
+		Limit(viper.GetInt("MAX_PAGE_SIZE")).
+		Find(&alerts).Error
+	if err != nil {
+		slog.Error("Failed to query latest alerts per host", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+	for _, alert := range alerts {
+		delete(alert, "host_rank")
+	}
+	decodeJSONColumns(alerts, jsonColumns...)
+	utcTimestamps(alerts)
+
+	resp := gin.H{
+		"module": module,
+		"hosts":  alerts,
+	}
+	if appliedRange != nil {
+		resp["defaultRange"] = appliedRange
+	}
+	respondJSON(c, http.StatusOK, resp)
+}
@@ -1,61 +1,108 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"reflect"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/spf13/viper"
 	"github.com/swaggo/files"
 	"github.com/swaggo/gin-swagger"
+	"gorm.io/datatypes"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+	"gorm.io/plugin/dbresolver"
 
-	_ "monitor-web/docs" // Import generated Swagger docs (generated by `swag init`)
+	"monitor-web/docs" // Generated Swagger docs (generated by `swag init`)
 )
 
 // AlertEvent represents the structure of incoming alert events from monitor-service
 type AlertEvent struct {
-	Timestamp        time.Time `json:"timestamp"`
-	Module           string    `json:"module"`
-	ServiceName      string    `json:"service_name"`
-	EventName        string    `json:"event_name"`
-	Details          string    `json:"details"`
-	HostIP           string    `json:"host_ip"`
-	AlertType        string    `json:"alert_type"`
-	ClusterName      string    `json:"cluster_name"`
-	Hostname         string    `json:"hostname"`
-	BigKeysCount     *int      `json:"big_keys_count,omitempty"`      // Redis-specific
-	FailedNodes      *string   `json:"failed_nodes,omitempty"`        // Redis-specific
-	DeadlocksInc     *int64    `json:"deadlocks_increment,omitempty"` // MySQL-specific
-	SlowQueriesInc   *int64    `json:"slow_queries_increment,omitempty"`
-	Connections      *int      `json:"connections,omitempty"`
-	CPUUsage         *float64  `json:"cpu_usage,omitempty"`     // Host-specific
-	MemRemaining     *float64  `json:"mem_remaining,omitempty"`
-	DiskUsage        *float64  `json:"disk_usage,omitempty"`
-	AddedUsers       *string   `json:"added_users,omitempty"`    // System-specific
-	RemovedUsers     *string   `json:"removed_users,omitempty"`
-	AddedProcesses   *string   `json:"added_processes,omitempty"`
-	RemovedProcesses *string   `json:"removed_processes,omitempty"`
+	AlertCommon
+	AlertModuleData
+}
+
+// AlertCommon holds the fields shared by every module's alert events
+type AlertCommon struct {
+	Timestamp   time.Time         `json:"timestamp"`
+	Module      string            `json:"module"`
+	ServiceName string            `json:"service_name"`
+	EventName   string            `json:"event_name"`
+	Details     string            `json:"details"`
+	HostIP      string            `json:"host_ip"`
+	HostIPs     []string          `json:"host_ips,omitempty"` // Additional hosts for cluster-level alerts
+	AlertType   string            `json:"alert_type"`
+	ClusterName string            `json:"cluster_name"`
+	Hostname    string            `json:"hostname"`
+	State       string            `json:"state,omitempty"`   // firing (default) or resolved
+	Labels      map[string]string `json:"labels,omitempty"`  // Arbitrary routing/filtering labels, e.g. team=payments
+	FanOut      bool              `json:"fan_out,omitempty"` // Store one alert per host in host_ips, sharing an incident_id
+
+	AttachmentURL string `json:"attachment_url,omitempty"` // Link to e.g. a graph screenshot
+	Attachment    string `json:"attachment,omitempty"`     // Base64 blob, stored under ATTACHMENT_DIR
+}
+
+// AlertModuleData holds the module-specific alert fields; the module tag names the module
+// each field applies to
+type AlertModuleData struct {
+	BigKeysCount     *int     `json:"big_keys_count,omitempty" module:"redis"`
+	FailedNodes      *string  `json:"failed_nodes,omitempty" module:"redis"`
+	DeadlocksInc     *int64   `json:"deadlocks_increment,omitempty" module:"mysql"`
+	SlowQueriesInc   *int64   `json:"slow_queries_increment,omitempty" module:"mysql"`
+	Connections      *int     `json:"connections,omitempty" module:"mysql"`
+	CPUUsage         *float64 `json:"cpu_usage,omitempty" module:"host"`
+	MemRemaining     *float64 `json:"mem_remaining,omitempty" module:"host"`
+	DiskUsage        *float64 `json:"disk_usage,omitempty" module:"host"`
+	AddedUsers       *string  `json:"added_users,omitempty" module:"system"`
+	RemovedUsers     *string  `json:"removed_users,omitempty" module:"system"`
+	AddedProcesses   *string  `json:"added_processes,omitempty" module:"system"`
+	RemovedProcesses *string  `json:"removed_processes,omitempty" module:"system"`
 }
 
 // Alert is the general alerts table model
 type Alert struct {
-	ID          uint64    `gorm:"primaryKey;autoIncrement"`
-	Timestamp   time.Time `gorm:"index;not null"`
-	Module      string    `gorm:"index;not null;size:50"`
-	ServiceName string    `gorm:"not null;size:100"`
-	EventName   string    `gorm:"not null;size:100"`
-	Details     string    `gorm:"not null;type:text"`
-	HostIP      string    `gorm:"not null;size:50"`
-	AlertType   string    `gorm:"not null;size:50"`
-	ClusterName string    `gorm:"not null;size:100"`
-	Hostname    string    `gorm:"not null;size:100"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	ID             uint64    `gorm:"primaryKey;autoIncrement"`
+	Timestamp      time.Time `gorm:"index;not null"`
+	Module         string    `gorm:"index;not null;size:50"`
+	ServiceName    string    `gorm:"not null;size:100"`
+	EventName      string    `gorm:"not null;size:100"`
+	Details        string    `gorm:"not null;type:text"`
+	HostIP         string    `gorm:"not null;size:50"`
+	HostIPs        datatypes.JSONSlice[string]
+	AlertType      string `gorm:"not null;size:50"` // Canonical, folded by normalizeAlertType
+	RawAlertType   string `gorm:"size:50"`          // As sent
+	ClusterName    string `gorm:"not null;size:100"`
+	Hostname       string `gorm:"not null;size:100"`
+	OwnerTeam      string `gorm:"index;size:100"`                    // From the SERVICE_OWNERS catalog at ingest
+	Environment    string `gorm:"index;size:50"`                     // From the host's CMDB_SOURCE entry at ingest
+	Role           string `gorm:"index;size:100"`                    // From the host's CMDB_SOURCE entry at ingest
+	HostOwner      string `gorm:"index;size:100"`                    // From the host's CMDB_SOURCE entry at ingest
+	IncidentID     string `gorm:"index;size:32"`                     // Shared by the alerts fanned out from one event
+	Duplicates     int    `gorm:"not null;default:0"`                // Repeats ignored under ENFORCE_UNIQUE
+	Fingerprint    string `gorm:"index;not null;size:64;default:''"` // alertFingerprint of the FINGERPRINT_FIELDS
+	AttachmentURL  string `gorm:"size:2048"`
+	AttachmentFile string `gorm:"size:32"` // Blob name under ATTACHMENT_DIR
+	AttachmentType string `gorm:"size:100"`
+	Labels         datatypes.JSON
+	Suppressed     bool   `gorm:"default:false"` // Set when the alert's host was muted at ingest
+	Status         string `gorm:"index;not null;size:20;default:firing"`
+	ResolvedAt     *time.Time
+	AckedBy        string `gorm:"size:100"`
+	AckedAt        *time.Time
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
 }
 
 // RedisAlert is the Redis-specific alerts table model
@@ -90,11 +137,35 @@ type SystemAlert struct {
 	RemovedProcesses string `gorm:"type:text"`
 }
 
+// alertRecord is implemented by every alerts table model through the embedded Alert
+type alertRecord interface {
+	base() *Alert
+}
+
+func (a *Alert) base() *Alert { return a }
+
+// alertModels are the alerts table models managed by migrations
+var alertModels = []interface{}{&Alert{}, &RedisAlert{}, &MySQLAlert{}, &HostAlert{}, &SystemAlert{}}
+
 var db *gorm.DB
 
+// trustedProxies are the proxy IPs/CIDRs whose forwarding headers determine the client IP
+var trustedProxies []string
+
+// defaultRange is the lookback applied to reads when no from/to is given (0 disables it)
+var defaultRange time.Duration
+
+// dbLogLevel is the DB_LOG_LEVEL at which gorm logs SQL through slog
+var dbLogLevel logger.LogLevel
+
+// maxEventAge is how old an event's timestamp may be before ingest rejects it (0 disables it)
+var maxEventAge time.Duration
+
 // @title Monitor Web API
 // @version 1.0
 // @description API for receiving and querying alert events for monitoring services.
+// @description All timestamps in responses are UTC, formatted as RFC 3339.
+// @description With RESPONSE_ENVELOPE enabled, JSON responses are wrapped as {api_version, data, meta, error}; api_version only changes on breaking changes, so clients must ignore unknown fields.
 // @host localhost:8080
 // @BasePath /api
 func main() {
@@ -117,33 +188,142 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Auto-migrate tables
-	if err := db.AutoMigrate(&Alert{}, &RedisAlert{}, &MySQLAlert{}, &HostAlert{}, &SystemAlert{}); err != nil {
+	// Auto-migrate tables, or only report the pending DDL in dry-run mode
+	models := append([]interface{}{&HostMute{}, &SchemaMigration{}}, alertModels...)
+	if viper.GetBool("MIGRATE_DRY_RUN") {
+		statements, err := planMigration(models...)
+		if err != nil {
+			slog.Error("Failed to plan migration", "error", err, "component", "monitor-web")
+			os.Exit(1)
+		}
+		for _, sql := range statements {
+			slog.Info("Pending migration statement", "sql", sql, "component", "monitor-web")
+		}
+		pending, err := pendingDataMigrations()
+		if err != nil {
+			slog.Error("Failed to plan data migrations", "error", err, "component", "monitor-web")
+			os.Exit(1)
+		}
+		for _, version := range pending {
+			slog.Info("Pending data migration", "version", version, "component", "monitor-web")
+		}
+		slog.Info("Migration dry run complete; no changes applied", "statements", len(statements), "component", "monitor-web")
+		os.Exit(0)
+	}
+	if err := migrateModels(models...); err != nil {
 		slog.Error("Failed to auto-migrate tables", "error", err, "component", "monitor-web")
 		os.Exit(1)
 	}
+	if viper.GetBool("ENFORCE_UNIQUE") {
+		if err := ensureUniqueIndexes(alertModels...); err != nil {
+			slog.Error("Failed to create unique alert indexes", "error", err, "component", "monitor-web")
+			os.Exit(1)
+		}
+	}
+	if viper.GetBool("ENABLE_FULLTEXT") {
+		if err := ensureFulltextIndexes(alertModels...); err != nil {
+			slog.Error("Failed to create full-text alert indexes", "error", err, "component", "monitor-web")
+			os.Exit(1)
+		}
+	}
+	if err := backfillFingerprints(alertModels...); err != nil {
+		slog.Error("Failed to backfill alert fingerprints", "error", err, "component", "monitor-web")
+		os.Exit(1)
+	}
+	if err := runDataMigrations(); err != nil {
+		slog.Error("Failed to run data migrations", "error", err, "component", "monitor-web")
+		os.Exit(1)
+	}
 	slog.Info("Database tables migrated successfully", "component", "monitor-web")
 
-	// Initialize Gin router
-	r := gin.Default()
+	r, err := newRouter()
+	if err != nil {
+		slog.Error("Failed to set trusted proxies", "error", err, "component", "monitor-web")
+		os.Exit(1)
+	}
 
-	// Swagger endpoint
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	grpcLis, err := listenGRPC()
+	if err != nil {
+		slog.Error("Failed to start gRPC server", "error", err, "component", "monitor-web")
+		os.Exit(1)
+	}
 
-	// Routes
-	r.POST("/api/alerts", receiveAlert)
-	r.GET("/api/alerts/:module", getAlerts)
+	reloadOnSIGHUP(
+		catalog{"service owners", loadServiceOwners, func() int { return len(*serviceOwners.Load()) }},
+		catalog{"CMDB", loadCMDB, func() int { return len(*cmdbHosts.Load()) }},
+	)
+	replaySpoolPeriodically()
+
+	// Start servers
+	serve(r, grpcLis)
+}
 
-	// Start server
-	port := viper.GetString("WEB_PORT")
-	if port == "" {
-		port = "8080"
+// newRouter builds the Gin engine with its middleware and every route mounted under
+// API_BASE_PATH
+func newRouter() (*gin.Engine, error) {
+	r := gin.New()
+	r.Use(gin.Logger(), assignRequestID, trackLatency, recoverJSON)
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
 	}
-	slog.Info("Starting web server", "port", port, "component", "monitor-web")
-	if err := r.Run(":" + port); err != nil {
-		slog.Error("Failed to start web server", "error", err, "port", port, "component", "monitor-web")
-		os.Exit(1)
+	if max := viper.GetInt("MAX_CONCURRENT_REQUESTS"); max > 0 {
+		r.Use(limitConcurrency(max))
+	}
+	r.Use(noStoreAPI)
+
+	// Every route, including Swagger, lives under API_BASE_PATH
+	base := r.Group(apiBasePath)
+	docs.SwaggerInfo.BasePath = apiBasePath + "/api"
+
+	// Swagger endpoint
+	base.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	base.GET("/favicon.ico", serveFavicon(viper.GetString("FAVICON_PATH")))
+
+	// Routes
+	base.POST("/api/alerts", requireIngestSource, dedupRequestBody, receiveAlert)
+	base.POST("/api/alerts/import", requireIngestSource, dedupRequestBody, importAlerts)
+	base.POST("/api/alerts/columnar", requireIngestSource, dedupRequestBody, receiveColumnarAlerts)
+	base.POST("/api/v2/alerts", requireIngestSource, dedupRequestBody, receiveAlertV2)
+	base.POST("/api/alerts/grafana", requireIngestSource, dedupRequestBody, receiveGrafanaAlerts)
+	base.GET("/api/alerts/around", getAlertsAround)
+	base.GET("/api/alerts/correlations", getAlertCorrelations)
+	base.GET("/api/alerts/:module", getAlerts)
+	base.GET("/api/alerts/:module/rate", getAlertRate)
+	base.GET("/api/alerts/:module/by-event", getAlertsByEvent)
+	base.GET("/api/alerts/:module/latest-per-host", getLatestPerHost)
+	base.GET("/api/alerts/:module/mttr", getMTTR)
+	base.GET("/api/alerts/:module/compare", getAlertCompare)
+	base.GET("/api/alerts/:module/chart", getAlertChart)
+	base.GET("/api/alerts/:module/heatmap", getAlertHeatmap)
+	base.GET("/api/alerts/:module/forecast", getAlertForecast)
+	base.GET("/api/alerts/:module/metrics", getAlertMetrics)
+	base.GET("/api/alerts/:module/since", getAlertsSince)
+	base.GET("/api/alerts/:module/export.xlsx", exportAlertsXLSX)
+	base.GET("/api/alerts/:module/export.ics", exportAlertsICS)
+	base.POST("/api/alerts/:module/import.csv", requireIngestSource, dedupRequestBody, importAlertsCSV)
+	base.POST("/api/alerts/:module/ack-bulk", ackAlertsBulk)
+	base.GET("/api/alerts/:module/:id/ack", ackAlertByLink)
+	base.GET("/api/alerts/:module/:id/attachment", getAlertAttachment)
+	base.GET("/api/alerts/:module/:id/report", getAlertReport)
+	base.GET("/api/feed", getFeed)
+	base.GET("/api/tail", getTail)
+	base.GET("/api/stats/storage", getStorageStats)
+	base.GET("/api/stats/latency", getLatencyStats)
+	base.GET("/api/stats/dedup", getDedupStats)
+	base.GET("/api/stats/skew", getSkewStats)
+	base.GET("/api/stats/cache", getFilterCacheStats)
+	base.GET("/api/schema", getSchema)
+	base.GET("/api/ui-config", getUIConfig)
+	base.POST("/api/test-alert", requireAdmin, sendTestAlert)
+	base.GET("/api/admin/backup.ndjson.gz", requireAdmin, backupAlerts)
+	base.GET("/api/hosts/muted", listMutedHosts)
+	base.GET("/api/hosts/alerting", getAlertingHosts)
+	base.POST("/api/hosts/:host_ip/mute", muteHost)
+	base.DELETE("/api/hosts/:host_ip/mute", unmuteHost)
+	if viper.GetBool("EXPOSE_ROUTES") {
+		base.GET("/api/routes", listRoutes(r))
 	}
+	return r, nil
 }
 
 // initConfig loads configuration from environment variables
@@ -164,6 +344,153 @@ func initConfig() error {
 	viper.SetDefault("DB_PORT", "3306")
 	viper.SetDefault("DB_PASS", "")
 	viper.SetDefault("WEB_PORT", "8080")
+	viper.SetDefault("DEFAULT_RANGE", "7d")
+	viper.SetDefault("APP_ENV", "production")
+	viper.SetDefault("ENFORCE_UNIQUE", false)
+	viper.SetDefault("ENABLE_FULLTEXT", false)
+	viper.SetDefault("MAX_DETAILS_BYTES", 65535) // 64KB, the MySQL TEXT column limit
+	viper.SetDefault("EXPOSE_ROUTES", false)
+	viper.SetDefault("MAX_PAGE_SIZE", 1000)
+	viper.SetDefault("MIGRATE_DRY_RUN", false)
+	viper.SetDefault("MAX_CONCURRENT_REQUESTS", 200) // 0 disables the limit
+	viper.SetDefault("MAX_EVENT_AGE", "0")           // 0 accepts events of any age
+	viper.SetDefault("CLOCK_SKEW_WARN", "0")         // 0 disables skew warnings
+	viper.SetDefault("CLOCK_SKEW_REJECT", "0")       // 0 accepts events of any skew
+	viper.SetDefault("STRICT_SCHEMA", false)
+	viper.SetDefault("DB_LOG_LEVEL", "warn")
+	viper.SetDefault("DB_TABLE_CHARSET", "utf8mb4") // Matches the connection charset
+	viper.SetDefault("RESPONSE_ENVELOPE", false)
+	viper.SetDefault("READ_CACHE_TTL", "0")     // 0 disables serving stale reads
+	viper.SetDefault("FILTER_CACHE_TTL", "60s") // 0 disables caching aggregate reads
+	viper.SetDefault("BODY_DEDUP_WINDOW", "0")  // 0 ingests repeated request bodies again
+	viper.SetDefault("DASHBOARD_LIMIT", defaultDashboardLimit)
+	viper.SetDefault("SEVERITY_ORDER", "critical,error,warning,info") // Most severe first
+	viper.SetDefault("FINGERPRINT_FIELDS", "module,service_name,event_name,host_ip,cluster_name")
+	viper.SetDefault("ALERT_TYPES", "critical,error,warning,info,test")
+	viper.SetDefault("ALERT_TYPE_ALIASES", "crit=critical,fatal=critical,err=error,warn=warning,information=info")
+	viper.SetDefault("INSERT_RETRIES", 2)
+	viper.SetDefault("MAX_LIST_ENTRIES", 0) // 0 stores lists of any length
+	viper.SetDefault("HOST_METRIC_EPSILON", "0")
+	viper.SetDefault("ACK_LINK_TTL", "24h")
+	viper.SetDefault("GRAFANA_MODULE_LABEL", "module")
+	viper.SetDefault("GRAFANA_SEVERITY_LABEL", "severity")
+	viper.SetDefault("GRAFANA_DEFAULT_MODULE", "general")
+	viper.SetDefault("SLOW_REQUEST_THRESHOLD", "1s") // 0 disables the slow-request log
+	viper.SetDefault("MAX_ATTACHMENT_BYTES", 1<<20)
+	viper.SetDefault("ATTACHMENT_TYPES", "image/png,image/jpeg,image/gif,image/webp")
+	viper.SetDefault("SPOOL_REPLAY_INTERVAL", "30s")
+
+	trustedProxies = splitList(viper.GetString("TRUSTED_PROXIES"))
+	for _, p := range trustedProxies {
+		if _, _, err := net.ParseCIDR(p); err != nil && net.ParseIP(p) == nil {
+			return fmt.Errorf("invalid MONITOR_WEB_TRUSTED_PROXIES entry %q: not an IP or CIDR", p)
+		}
+	}
+
+	if viper.GetInt("MAX_PAGE_SIZE") < 1 {
+		return fmt.Errorf("MONITOR_WEB_MAX_PAGE_SIZE must be positive")
+	}
+	if limit := viper.GetInt("DASHBOARD_LIMIT"); limit < 1 || limit > viper.GetInt("MAX_PAGE_SIZE") {
+		return fmt.Errorf("MONITOR_WEB_DASHBOARD_LIMIT must be between 1 and MONITOR_WEB_MAX_PAGE_SIZE")
+	}
+	if err := initRedaction(); err != nil {
+		return err
+	}
+	if err := initIngestAllowList(); err != nil {
+		return err
+	}
+	if err := initModuleAliases(); err != nil {
+		return err
+	}
+	if err := initIngestKeys(); err != nil {
+		return err
+	}
+	if err := initSeverityColors(); err != nil {
+		return err
+	}
+	initSeverityOrder()
+	if err := initTableOptions(); err != nil {
+		return err
+	}
+	if err := initTablePrefix(); err != nil {
+		return err
+	}
+	if err := initBasePath(); err != nil {
+		return err
+	}
+	if err := initListeners(); err != nil {
+		return err
+	}
+	if err := initNormalization(); err != nil {
+		return err
+	}
+	if err := initAlertTypes(); err != nil {
+		return err
+	}
+	if err := initSeverityRules(); err != nil {
+		return err
+	}
+	if err := initFingerprint(); err != nil {
+		return err
+	}
+	if err := initChangeDetection(); err != nil {
+		return err
+	}
+	if err := loadServiceOwners(); err != nil {
+		return err
+	}
+	if err := loadCMDB(); err != nil {
+		return err
+	}
+	if err := initSpool(); err != nil {
+		return err
+	}
+	if err := initAttachments(); err != nil {
+		return err
+	}
+	if _, ok := moduleTable(canonicalModule(viper.GetString("GRAFANA_DEFAULT_MODULE"))); !ok {
+		return fmt.Errorf("invalid MONITOR_WEB_GRAFANA_DEFAULT_MODULE %q: unknown module", viper.GetString("GRAFANA_DEFAULT_MODULE"))
+	}
+	if viper.GetInt("INSERT_RETRIES") < 0 {
+		return fmt.Errorf("MONITOR_WEB_INSERT_RETRIES must not be negative")
+	}
+	if path := viper.GetString("FAVICON_PATH"); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("invalid MONITOR_WEB_FAVICON_PATH: %w", err)
+		}
+	}
+
+	var err error
+	if defaultRange, err = parseDuration(viper.GetString("DEFAULT_RANGE")); err != nil {
+		return fmt.Errorf("invalid MONITOR_WEB_DEFAULT_RANGE: %w", err)
+	}
+	if maxEventAge, err = parseDuration(viper.GetString("MAX_EVENT_AGE")); err != nil {
+		return fmt.Errorf("invalid MONITOR_WEB_MAX_EVENT_AGE: %w", err)
+	}
+	if clockSkewWarn, err = parseDuration(viper.GetString("CLOCK_SKEW_WARN")); err != nil {
+		return fmt.Errorf("invalid MONITOR_WEB_CLOCK_SKEW_WARN: %w", err)
+	}
+	if clockSkewReject, err = parseDuration(viper.GetString("CLOCK_SKEW_REJECT")); err != nil {
+		return fmt.Errorf("invalid MONITOR_WEB_CLOCK_SKEW_REJECT: %w", err)
+	}
+	if dbLogLevel, err = parseDBLogLevel(viper.GetString("DB_LOG_LEVEL")); err != nil {
+		return fmt.Errorf("invalid MONITOR_WEB_DB_LOG_LEVEL: %w", err)
+	}
+	if readCacheTTL, err = parseDuration(viper.GetString("READ_CACHE_TTL")); err != nil {
+		return fmt.Errorf("invalid MONITOR_WEB_READ_CACHE_TTL: %w", err)
+	}
+	if filterCacheTTL, err = parseDuration(viper.GetString("FILTER_CACHE_TTL")); err != nil {
+		return fmt.Errorf("invalid MONITOR_WEB_FILTER_CACHE_TTL: %w", err)
+	}
+	if bodyDedupWindow, err = parseDuration(viper.GetString("BODY_DEDUP_WINDOW")); err != nil {
+		return fmt.Errorf("invalid MONITOR_WEB_BODY_DEDUP_WINDOW: %w", err)
+	}
+	if slowRequestThreshold, err = parseDuration(viper.GetString("SLOW_REQUEST_THRESHOLD")); err != nil {
+		return fmt.Errorf("invalid MONITOR_WEB_SLOW_REQUEST_THRESHOLD: %w", err)
+	}
+	if ackLinkTTL, err = parseDuration(viper.GetString("ACK_LINK_TTL")); err != nil || ackLinkTTL <= 0 {
+		return fmt.Errorf("invalid MONITOR_WEB_ACK_LINK_TTL %q: want a positive duration", viper.GetString("ACK_LINK_TTL"))
+	}
 
 	// Log loaded configuration (excluding sensitive data like DB_PASS)
 	slog.Info("Configuration loaded",
@@ -171,14 +498,109 @@ func initConfig() error {
 		"DB_PORT", viper.GetString("DB_PORT"),
 		"DB_NAME", viper.GetString("DB_NAME"),
 		"DB_USER", viper.GetString("DB_USER"),
-		"WEB_PORT", viper.GetString("WEB_PORT"),
+		"WEB_PORT", webPort,
+		"TLS_PORT", tlsPort,
+		"TLS_CERT_FILE", tlsCertFile,
+		"DEFAULT_RANGE", viper.GetString("DEFAULT_RANGE"),
+		"APP_ENV", viper.GetString("APP_ENV"),
+		"TRUSTED_PROXIES", trustedProxies,
+		"ENFORCE_UNIQUE", viper.GetBool("ENFORCE_UNIQUE"),
+		"ENABLE_FULLTEXT", viper.GetBool("ENABLE_FULLTEXT"),
+		"DB_REPLICA", viper.GetString("DB_REPLICA_DSN") != "",
+		"MAX_DETAILS_BYTES", viper.GetInt("MAX_DETAILS_BYTES"),
+		"MAX_LIST_ENTRIES", viper.GetInt("MAX_LIST_ENTRIES"),
+		"CHANGE_DETECT_MODULES", viper.GetString("CHANGE_DETECT_MODULES"),
+		"HOST_METRIC_EPSILON", metricEpsilon,
+		"EXPOSE_ROUTES", viper.GetBool("EXPOSE_ROUTES"),
+		"REDACT_PATTERNS", len(redactPatterns),
+		"MAX_PAGE_SIZE", viper.GetInt("MAX_PAGE_SIZE"),
+		"DASHBOARD_LIMIT", viper.GetInt("DASHBOARD_LIMIT"),
+		"INGEST_ALLOWED_CIDRS", viper.GetString("INGEST_ALLOWED_CIDRS"),
+		"MAX_CONCURRENT_REQUESTS", viper.GetInt("MAX_CONCURRENT_REQUESTS"),
+		"MAX_EVENT_AGE", maxEventAge.String(),
+		"CLOCK_SKEW_WARN", clockSkewWarn.String(),
+		"CLOCK_SKEW_REJECT", clockSkewReject.String(),
+		"MODULE_ALIASES", moduleAliases,
+		"INGEST_KEY", ingestKey != "",
+		"INGEST_KEYS", len(moduleIngestKeys),
+		"FAVICON_PATH", viper.GetString("FAVICON_PATH"),
+		"STRICT_SCHEMA", viper.GetBool("STRICT_SCHEMA"),
+		"DB_LOG_LEVEL", viper.GetString("DB_LOG_LEVEL"),
+		"SEVERITY_COLORS", severityColors,
+		"SEVERITY_ORDER", viper.GetString("SEVERITY_ORDER"),
+		"SERVICE_OWNERS", viper.GetString("SERVICE_OWNERS"),
+		"CMDB_SOURCE", viper.GetString("CMDB_SOURCE"),
+		"DB_TABLE_OPTIONS", defaultTableOptions,
+		"DB_TABLE_OPTIONS_OVERRIDES", moduleTableOptions,
+		"DB_SCHEMA", viper.GetString("DB_SCHEMA"),
+		"API_BASE_PATH", apiBasePath,
+		"GRPC_PORT", viper.GetString("GRPC_PORT"),
+		"RESPONSE_ENVELOPE", viper.GetBool("RESPONSE_ENVELOPE"),
+		"ADMIN_KEY", viper.GetString("ADMIN_KEY") != "",
+		"ACK_LINK_SECRET", viper.GetString("ACK_LINK_SECRET") != "",
+		"ACK_LINK_TTL", ackLinkTTL.String(),
+		"GRAFANA_MODULE_LABEL", viper.GetString("GRAFANA_MODULE_LABEL"),
+		"GRAFANA_SEVERITY_LABEL", viper.GetString("GRAFANA_SEVERITY_LABEL"),
+		"GRAFANA_DEFAULT_MODULE", viper.GetString("GRAFANA_DEFAULT_MODULE"),
+		"SLOW_REQUEST_THRESHOLD", slowRequestThreshold.String(),
+		"ATTACHMENT_DIR", attachmentDir,
+		"MAX_ATTACHMENT_BYTES", viper.GetInt("MAX_ATTACHMENT_BYTES"),
+		"ATTACHMENT_TYPES", viper.GetString("ATTACHMENT_TYPES"),
+		"NORMALIZE_FIELDS", viper.GetString("NORMALIZE_FIELDS"),
+		"FINGERPRINT_FIELDS", strings.Join(fingerprintFields, ","),
+		"ALERT_TYPES", viper.GetString("ALERT_TYPES"),
+		"ALERT_TYPE_ALIASES", viper.GetString("ALERT_TYPE_ALIASES"),
+		"ALERT_TYPE_DEFAULT", defaultAlertType,
+		"SEVERITY_RULES", severityRuleCounts(),
+		"READ_CACHE_TTL", readCacheTTL.String(),
+		"FILTER_CACHE_TTL", filterCacheTTL.String(),
+		"BODY_DEDUP_WINDOW", bodyDedupWindow.String(),
+		"INSERT_RETRIES", viper.GetInt("INSERT_RETRIES"),
+		"SPOOL_DIR", spoolDir,
+		"SPOOL_REPLAY_INTERVAL", viper.GetString("SPOOL_REPLAY_INTERVAL"),
 		"component", "monitor-web",
 	)
 
 	return nil
 }
 
-// initDB initializes the MySQL database connection using environment variables
+// splitList splits a comma-separated config value, trimming blanks and dropping empty entries
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// parseDuration extends time.ParseDuration with day ("7d") and week ("2w") units
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" || s == "0" {
+		return 0, nil
+	}
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// initDB initializes the MySQL database connection using environment variables,
+// adding a read replica when DB_REPLICA_DSN is set
 func initDB() (*gorm.DB, error) {
 	dsn := fmt.Sprintf(
 		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
@@ -190,6 +612,8 @@ func initDB() (*gorm.DB, error) {
 	)
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
 		DisableForeignKeyConstraintWhenMigrating: true,
+		NamingStrategy:                           schema.NamingStrategy{TablePrefix: tablePrefix},
+		Logger:                                   slogGormLogger{level: dbLogLevel},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to MySQL: %w", err)
@@ -202,49 +626,191 @@ func initDB() (*gorm.DB, error) {
 	sqlDB.SetMaxIdleConns(10)
 	sqlDB.SetMaxOpenConns(100)
 	sqlDB.SetConnMaxLifetime(time.Hour)
+
+	// Route reads to the replica when one is configured; writes stay on the primary
+	if replicaDSN := viper.GetString("DB_REPLICA_DSN"); replicaDSN != "" {
+		if err := useReplica(db, mysql.Open(replicaDSN)); err != nil {
+			return nil, fmt.Errorf("failed to configure read replica: %w", err)
+		}
+	}
 	return db, nil
 }
 
+// useReplica routes db's reads to replica; writes and dbresolver.Write queries stay on the primary
+func useReplica(db *gorm.DB, replica gorm.Dialector) error {
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{replica},
+	}).
+		SetMaxIdleConns(10).
+		SetMaxOpenConns(100).
+		SetConnMaxLifetime(time.Hour)
+	return db.Use(resolver)
+}
+
 // receiveAlert godoc
 // @Summary Receive and store an alert event
-// @Description Handles incoming alert events and stores them in the appropriate database table based on the module.
+// @Description Stores an alert event in its module's table or, with state "resolved", resolves the most recent open alert with the same fingerprint. The response status says whether it was stored, resolved, spooled (202), or skipped as unchanged or duplicate.
 // @Tags alerts
 // @Accept json
 // @Produce json
 // @Param alert body AlertEvent true "Alert Event"
-// @Success 200 {object} map[string]string
+// @Param X-Schema-Version header string false "Payload schema version: 1 (flat, default) or 2 (nested)"
+// @Param X-Ingest-Key header string false "Shared secret for the event's module, required when INGEST_KEY or INGEST_KEYS covers it"
+// @Success 200 {object} map[string]interface{}
+// @Success 202 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /alerts [post]
 func receiveAlert(c *gin.Context) {
-	var event AlertEvent
-	if err := c.ShouldBindJSON(&event); err != nil {
-		slog.Error("Failed to parse alert JSON", "error", err, "component", "monitor-web")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+	switch version := c.GetHeader(schemaVersionHeader); version {
+	case "", "1":
+	case "2":
+		receiveAlertV2(c)
+		return
+	default:
+		slog.Warn("Unsupported alert schema version", "version", version, "client_ip", c.ClientIP(), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Unsupported schema version"})
 		return
 	}
 
-	// Validate required fields
-	if event.Module == "" || event.ServiceName == "" || event.EventName == "" {
-		slog.Error("Missing required fields in alert", "module", event.Module, "component", "monitor-web")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields"})
+	var event AlertEvent
+	if !bindAlertBody(c, &event) {
 		return
 	}
+	storeEvent(c, &event)
+}
 
-	// Common alert fields
-	alert := Alert{
-		Timestamp:   event.Timestamp,
-		Module:      event.Module,
-		ServiceName: event.ServiceName,
-		EventName:   event.EventName,
-		Details:     event.Details,
-		HostIP:      event.HostIP,
-		AlertType:   event.AlertType,
-		ClusterName: event.ClusterName,
-		Hostname:    event.Hostname,
+// bindAlertBody binds the JSON request body into obj, a struct pointer, answering 400 with
+// the binding details (exposed only when APP_ENV is dev) when it does not parse, or with the
+// offending keys when STRICT_SCHEMA is on and it carries fields obj does not declare
+func bindAlertBody(c *gin.Context, obj interface{}) bool {
+	if err := c.ShouldBindBodyWith(obj, binding.JSON); err != nil {
+		body, _ := c.Get(gin.BodyBytesKey)
+		bodyBytes, _ := body.([]byte)
+		details := bindingErrorDetails(err, bodyBytes)
+		slog.Error("Failed to parse alert JSON", "error", err, "details", details, "client_ip", c.ClientIP(), "component", "monitor-web")
+		resp := gin.H{"error": "Invalid JSON"}
+		if viper.GetString("APP_ENV") == "dev" {
+			resp["details"] = details
+		}
+		respondJSON(c, http.StatusBadRequest, resp)
+		return false
+	}
+	if viper.GetBool("STRICT_SCHEMA") {
+		body, _ := c.Get(gin.BodyBytesKey)
+		bodyBytes, _ := body.([]byte)
+		if unknown := unknownFields(bodyBytes, reflect.TypeOf(obj).Elem()); len(unknown) > 0 {
+			slog.Warn("Rejected alert with unknown fields", "fields", unknown, "client_ip", c.ClientIP(), "component", "monitor-web")
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": "Unknown fields", "fields": unknown})
+			return false
+		}
+	}
+	return true
+}
+
+// storeEvent validates a bound event and stores or resolves it, whatever schema it arrived in
+func storeEvent(c *gin.Context, event *AlertEvent) {
+	code, resp := processEvent(c, event)
+	respondJSON(c, code, resp)
+}
+
+// processEvent is storeEvent without the response: it returns the status code and body,
+// so adapters that carry several events per request can collect one outcome per event
+func processEvent(c *gin.Context, event *AlertEvent) (int, gin.H) {
+	return ingestEvent(event, c.ClientIP(), c.GetHeader(ingestKeyHeader))
+}
+
+// ingestEvent validates and stores or resolves event on behalf of clientIP, which presented
+// the ingest key presentedKey. It returns the HTTP status and body, whatever the transport.
+func ingestEvent(event *AlertEvent, clientIP, presentedKey string) (int, gin.H) {
+	record, err := admitEvent(event, clientIP, ingestKeyCheck(presentedKey))
+	if errors.Is(err, errInvalidIngestKey) {
+		slog.Warn("Rejected alert with invalid ingest key", "module", event.Module, "client_ip", clientIP, "component", "monitor-web")
+		return http.StatusForbidden, gin.H{"error": err.Error()}
+	}
+	if err != nil {
+		var invalid *ingestError
+		if !errors.As(err, &invalid) {
+			slog.Error("Failed to prepare alert", "module", event.Module, "error", err, "client_ip", clientIP, "component", "monitor-web")
+			return http.StatusInternalServerError, gin.H{"error": "Failed to store alert"}
+		}
+		slog.Error("Rejected invalid alert", "module", event.Module, "error", err, "client_ip", clientIP, "component", "monitor-web")
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	}
+	suppressed := record.base().Suppressed
+
+	// A cluster event fanned out over its hosts becomes one alert per host
+	if event.FanOut && len(event.HostIPs) > 0 {
+		return storeFanOut(event, record, clientIP)
+	}
+
+	// A resolved event closes the matching open alert instead of adding a row
+	if event.State == statusResolved {
+		id, found, err := resolveAlert(record)
+		if err != nil {
+			slog.Error("Failed to resolve alert", "module", event.Module, "error", err, "client_ip", clientIP, "component", "monitor-web")
+			return http.StatusInternalServerError, gin.H{"error": "Failed to resolve alert"}
+		}
+		if !found {
+			slog.Warn("No open alert to resolve", "module", event.Module, "event_name", event.EventName, "host_ip", event.HostIP, "client_ip", clientIP, "component", "monitor-web")
+			return http.StatusOK, gin.H{"status": "unmatched"}
+		}
+		slog.Info("Resolved alert", "module", event.Module, "event_name", event.EventName, "id", id, "client_ip", clientIP, "component", "monitor-web")
+		return http.StatusOK, gin.H{"status": statusResolved, "id": id}
+	}
+
+	// Opted-in modules skip reports whose metrics have not moved since the last open alert
+	if id, err := unchangedAlertID(record); err != nil {
+		slog.Warn("Failed to compare alert metrics", "module", event.Module, "error", err, "client_ip", clientIP, "component", "monitor-web")
+	} else if id != 0 {
+		discardAttachment(record)
+		slog.Info("Unchanged alert ignored", "module", event.Module, "event_name", event.EventName, "id", id, "client_ip", clientIP, "component", "monitor-web")
+		return http.StatusOK, gin.H{"status": "unchanged", "id": id}
 	}
 
 	// Store in module-specific table
+	if err := createWithRetry(record); err != nil {
+		if viper.GetBool("ENFORCE_UNIQUE") && isDuplicateKeyError(err) {
+			if id, lookupErr := existingAlertID(record); lookupErr == nil {
+				discardAttachment(record)
+				if err := countDuplicate(record, id); err != nil {
+					slog.Warn("Failed to count duplicate alert", "module", event.Module, "id", id, "error", err, "client_ip", clientIP, "component", "monitor-web")
+				}
+				slog.Info("Duplicate alert ignored", "module", event.Module, "event_name", event.EventName, "id", id, "client_ip", clientIP, "component", "monitor-web")
+				return http.StatusOK, gin.H{"status": "duplicate", "id": id}
+			}
+		}
+		// With a spool the alert is kept on disk until the database is back; errors the
+		// database will return again are answered now instead
+		if spoolDir != "" && isTransientDBError(err) {
+			spoolErr := spoolAlert(event, record)
+			if spoolErr == nil {
+				slog.Warn("Spooled alert after insert failure", "module", event.Module, "event_name", event.EventName, "error", err, "client_ip", clientIP, "component", "monitor-web")
+				return http.StatusAccepted, gin.H{"status": "spooled"}
+			}
+			slog.Error("Failed to spool alert", "module", event.Module, "error", spoolErr, "client_ip", clientIP, "component", "monitor-web")
+		}
+		slog.Error("Failed to store alert", "module", event.Module, "error", err, "client_ip", clientIP, "component", "monitor-web")
+		discardAttachment(record)
+		status, msg := classifyDBError(err)
+		return status, gin.H{"error": msg}
+	}
+	slog.Info("Stored alert", "module", event.Module, "event_name", event.EventName, "suppressed", suppressed, "client_ip", clientIP, "component", "monitor-web")
+	resp := gin.H{"status": "stored", "id": record.base().ID}
+	if suppressed {
+		resp["suppressed"] = true
+	}
+	if ignored := foreignModuleFields(event); len(ignored) > 0 {
+		resp["ignoredFields"] = ignored
+	}
+	return http.StatusOK, resp
+}
+
+// newModuleRecord wraps the common alert fields in the module-specific model for event.Module,
+// falling back to the general alerts table for modules without one
+func newModuleRecord(alert Alert, event AlertEvent) alertRecord {
 	switch event.Module {
 	case "redis":
 		redisAlert := RedisAlert{
@@ -258,11 +824,7 @@ func receiveAlert(c *gin.Context) {
 		if event.FailedNodes != nil {
 			redisAlert.FailedNodes = *event.FailedNodes
 		}
-		if err := db.Create(&redisAlert).Error; err != nil {
-			slog.Error("Failed to store redis alert", "error", err, "component", "monitor-web")
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store alert"})
-			return
-		}
+		return &redisAlert
 	case "mysql":
 		mysqlAlert := MySQLAlert{
 			Alert:                alert,
@@ -279,11 +841,7 @@ func receiveAlert(c *gin.Context) {
 		if event.Connections != nil {
 			mysqlAlert.Connections = *event.Connections
 		}
-		if err := db.Create(&mysqlAlert).Error; err != nil {
-			slog.Error("Failed to store mysql alert", "error", err, "component", "monitor-web")
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store alert"})
-			return
-		}
+		return &mysqlAlert
 	case "host":
 		hostAlert := HostAlert{
 			Alert:        alert,
@@ -300,11 +858,7 @@ func receiveAlert(c *gin.Context) {
 		if event.DiskUsage != nil {
 			hostAlert.DiskUsage = *event.DiskUsage
 		}
-		if err := db.Create(&hostAlert).Error; err != nil {
-			slog.Error("Failed to store host alert", "error", err, "component", "monitor-web")
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store alert"})
-			return
-		}
+		return &hostAlert
 	case "system":
 		systemAlert := SystemAlert{
 			Alert:            alert,
@@ -325,136 +879,179 @@ func receiveAlert(c *gin.Context) {
 		if event.RemovedProcesses != nil {
 			systemAlert.RemovedProcesses = *event.RemovedProcesses
 		}
-		if err := db.Create(&systemAlert).Error; err != nil {
-			slog.Error("Failed to store system alert", "error", err, "component", "monitor-web")
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store alert"})
-			return
-		}
+		return &systemAlert
 	default:
-		if err := db.Create(&alert).Error; err != nil {
-			slog.Error("Failed to store general alert", "error", err, "component", "monitor-web")
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store alert"})
-			return
-		}
+		return &alert
 	}
-	slog.Info("Stored alert", "module", event.Module, "event_name", event.EventName, "component", "monitor-web")
-	c.JSON(http.StatusOK, gin.H{"status": "stored"})
 }
 
 // getAlerts godoc
 // @Summary Get alerts for a specific module
-// @Description Retrieves alerts and chart data for a given module, with optional filtering by date range and alert type.
+// @Description Retrieves alerts, the module's column definitions and chart data for a given module, with optional filtering by date range and alert type. Returns up to limit alerts per page (DASHBOARD_LIMIT by default), newest first; pass nextCursor back as cursor for the next page. With READ_CACHE_TTL set, a failed query is answered with the last successful response for the same URL, flagged stale: true.
 // @Tags alerts
 // @Accept json
 // @Produce json
 // @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
-// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param from query string false "Start date (YYYY-MM-DD); defaults to now minus DEFAULT_RANGE when from and to are omitted"
 // @Param to query string false "End date (YYYY-MM-DD)"
 // @Param alert_type query string false "Alert type filter"
+// @Param host_ip query string false "Host IP filter (matches host_ip or any of host_ips)"
+// @Param label.key query string false "Label filter, e.g. label.team=payments (repeatable for different keys)"
+// @Param q query string false "Search text in details (substring match, or full-text match with ENABLE_FULLTEXT)"
+// @Param owner_team query string false "Owning team filter (from SERVICE_OWNERS)"
+// @Param environment query string false "Host environment filter (from CMDB_SOURCE)"
+// @Param role query string false "Host role filter (from CMDB_SOURCE)"
+// @Param host_owner query string false "Host owner filter (from CMDB_SOURCE)"
+// @Param bucket query string false "Chart granularity: hour, day or week (weeks start on Monday)" default(day)
+// @Param cursor query string false "Opaque cursor from a previous response's nextCursor; returns the rows after it"
+// @Param limit query int false "Alerts per page, at most MAX_PAGE_SIZE; defaults to DASHBOARD_LIMIT"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /alerts/{module} [get]
 func getAlerts(c *gin.Context) {
-	module := c.Param("module")
-	var alerts []map[string]interface{}
-	tableName := module + "_alerts"
-	if module == "general" {
-		tableName = "alerts"
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
 	}
 
-	// Validate module
-	validModules := []string{"redis", "mysql", "host", "system", "general", "rabbitmq", "nacos"}
-	isValid := false
-	for _, m := range validModules {
-		if module == m {
-			isValid = true
-			break
-		}
+	bucket := c.DefaultQuery("bucket", "day")
+	bucketExpr, ok := chartBuckets[bucket]
+	if !ok {
+		slog.Warn("Invalid chart bucket", "bucket", bucket, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid bucket"})
+		return
 	}
-	if !isValid {
-		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid module"})
+
+	limit, err := parseLimit(c, viper.GetInt("DASHBOARD_LIMIT"))
+	if err != nil {
+		slog.Warn("Invalid alerts limit", "module", module, "limit", c.Query("limit"), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid limit"})
 		return
 	}
 
-	// Query parameters for filtering
-	from := c.Query("from")
-	to := c.Query("to")
-	alertType := c.Query("alert_type")
+	query := db.Table(tableName).Order("timestamp desc, id desc").Limit(limit)
+	query, appliedRange := applyAlertFilters(c, query)
 
-	query := db.Table(tableName).Order("timestamp desc").Limit(100)
-	if from != "" {
-		if t, err := time.Parse("2006-01-02", from); err == nil {
-			query = query.Where("timestamp >= ?", t)
-		} else {
-			slog.Warn("Invalid 'from' date format", "from", from, "component", "monitor-web")
+	// Keyset pagination: seek past the last row of the previous page
+	if raw := c.Query("cursor"); raw != "" {
+		cur, err := decodeCursor(raw)
+		if err != nil {
+			slog.Warn("Invalid alerts cursor", "module", module, "cursor", raw, "component", "monitor-web")
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
 		}
+		query = query.Where("(timestamp, id) < (?, ?)", cur.Timestamp, cur.ID)
 	}
-	if to != "" {
-		if t, err := time.Parse("2006-01-02", to); err == nil {
-			query = query.Where("timestamp <= ?", t)
-		} else {
-			slog.Warn("Invalid 'to' date format", "to", to, "component", "monitor-web")
+
+	alerts, err := findModuleAlerts(module, query)
+	if err != nil {
+		slog.Error("Failed to query alerts", "module", module, "error", err, "component", "monitor-web")
+		if serveStaleRead(c) {
+			return
 		}
-	}
-	if alertType != "" {
-		query = query.Where("alert_type = ?", alertType)
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
 	}
 
-	if err := query.Find(&alerts).Error; err != nil {
-		slog.Error("Failed to query alerts", "module", module, "error", err, "component", "monitor-web")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+	// Aggregate alerts per bucket in SQL so the chart covers the whole filtered range
+	buckets, _, err := queryChartBuckets(c, tableName, bucketExpr)
+	if err != nil {
+		slog.Error("Failed to aggregate alerts", "module", module, "bucket", bucket, "error", err, "component", "monitor-web")
+		if serveStaleRead(c) {
+			return
+		}
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
 		return
 	}
+	chartData := buildChartSpec(buckets, bucket)
 
-	// Prepare chart data
-	chartData := map[string]interface{}{
-		"labels": []string{},
-		"datasets": []map[string]interface{}{
-			{
-				"label":           "Alert Count",
-				"data":            []int{},
-				"borderColor":     "#3b82f6",
-				"backgroundColor": "#3b82f6",
-				"fill":            false,
-			},
-		},
+	// Return JSON response
+	resp := gin.H{
+		"module":    module,
+		"columns":   moduleColumns(module),
+		"alerts":    alerts,
+		"chartData": chartData,
+		"bucket":    bucket,
 	}
+	// A full page means there may be more rows below the last one
+	if len(alerts) == limit {
+		last := alerts[len(alerts)-1].base()
+		resp["nextCursor"] = encodeCursor(last.Timestamp, last.ID)
+	}
+	if appliedRange != nil {
+		resp["defaultRange"] = appliedRange
+	}
+	cacheRead(c, resp)
+	respondJSON(c, http.StatusOK, resp)
+}
 
-	// Aggregate alerts by day for chart
-	dayCounts := make(map[string]int)
-	for _, alert := range alerts {
-		ts, ok := alert["timestamp"].(time.Time)
-		if !ok {
-			continue
+// defaultDashboardLimit is the default DASHBOARD_LIMIT, the alerts per page of the listing
+const defaultDashboardLimit = 100
+
+// validModules lists the modules that can be queried
+var validModules = []string{"redis", "mysql", "host", "system", "general", "rabbitmq", "nacos"}
+
+// moduleTable returns the table holding alerts for module, prefixed per DB_SCHEMA, and whether the module is valid
+func moduleTable(module string) (string, bool) {
+	for _, m := range validModules {
+		if module == m {
+			if module == "general" {
+				return tablePrefix + "alerts", true
+			}
+			return tablePrefix + module + "_alerts", true
 		}
-		day := ts.Format("2006-01-02")
-		dayCounts[day]++
 	}
+	return "", false
+}
+
+// jsonColumns are the JSON-typed columns shared by every alerts table
+var jsonColumns = []string{"host_ips", "labels"}
 
-	// Sort days
-	var days []string
-	for day := range dayCounts {
-		days = append(days, day)
+// storedModules lists the modules that own an alerts table: general plus every module model
+func storedModules() []string {
+	modules := []string{"general"}
+	for module := range moduleModels {
+		modules = append(modules, module)
 	}
-	sort.Strings(days)
+	sort.Strings(modules[1:])
+	return modules
+}
 
-	// Populate chart data
-	labels := chartData["labels"].([]string)
-	dataset := chartData["datasets"].([]map[string]interface{})[0]
-	data := dataset["data"].([]int)
-	for _, day := range days {
-		labels = append(labels, day)
-		data = append(data, dayCounts[day])
+// utcTimestamps converts every time value in rows to UTC so API responses are
+// zone-independent RFC 3339 ("...Z") regardless of the DSN's loc=Local
+func utcTimestamps(rows []map[string]interface{}) {
+	for _, row := range rows {
+		for col, v := range row {
+			if t, ok := v.(time.Time); ok {
+				row[col] = t.UTC()
+			}
+		}
 	}
-	chartData["labels"] = labels
-	dataset["data"] = data
+}
 
-	// Return JSON response
-	c.JSON(http.StatusOK, gin.H{
-		"module":    module,
-		"alerts":    alerts,
-		"chartData": chartData,
-	})
-}
\ No newline at end of file
+// decodeJSONColumns replaces raw JSON column values scanned into maps with
+// json.RawMessage so they are emitted as JSON rather than quoted strings
+func decodeJSONColumns(rows []map[string]interface{}, columns ...string) {
+	for _, row := range rows {
+		for _, col := range columns {
+			var raw []byte
+			switch v := row[col].(type) {
+			case string:
+				raw = []byte(v)
+			case []byte:
+				raw = v
+			default:
+				continue
+			}
+			if len(raw) == 0 {
+				row[col] = nil
+				continue
+			}
+			row[col] = json.RawMessage(raw)
+		}
+	}
+}
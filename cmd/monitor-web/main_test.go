@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	os.Exit(m.Run())
+}
+
+// loadTestConfig runs initConfig with the required settings and env, MONITOR_WEB_-less
+// keys to values, set for the duration of the test
+func loadTestConfig(t *testing.T, env map[string]string) {
+	t.Helper()
+	t.Setenv("MONITOR_WEB_DB_NAME", "monitor")
+	t.Setenv("MONITOR_WEB_DB_USER", "monitor")
+	for key, value := range env {
+		t.Setenv("MONITOR_WEB_"+key, value)
+	}
+	if err := initConfig(); err != nil {
+		t.Fatalf("initConfig: %v", err)
+	}
+}
+
+// mockDialector returns a MySQL dialector over a sqlmock connection whose expectations
+// must all be met by the end of the test
+func mockDialector(t *testing.T) (gorm.Dialector, sqlmock.Sqlmock) {
+	t.Helper()
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		conn.Close()
+	})
+	return mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), mock
+}
+
+// mockDB points db at a sqlmock connection for the duration of the test
+func mockDB(t *testing.T) sqlmock.Sqlmock {
+	t.Helper()
+	dialector, mock := mockDialector(t)
+	mocked, err := gorm.Open(dialector, &gorm.Config{
+		NamingStrategy:         schema.NamingStrategy{TablePrefix: tablePrefix},
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+	prev := db
+	db = mocked
+	t.Cleanup(func() { db = prev })
+	return mock
+}
+
+// serveRequest runs one request through r and returns the recorded response
+func serveRequest(r http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// emptyRows is a result set with no rows
+func emptyRows(columns ...string) *sqlmock.Rows {
+	return sqlmock.NewRows(columns)
+}
+
+func TestAlertReadsUseReplica(t *testing.T) {
+	loadTestConfig(t, nil)
+	primary := mockDB(t)
+	replicaDialector, replica := mockDialector(t)
+	if err := useReplica(db, replicaDialector); err != nil {
+		t.Fatalf("useReplica: %v", err)
+	}
+
+	// The listing and its chart aggregate both read from the replica
+	replica.ExpectQuery("SELECT \\* FROM `redis_alerts`").WillReturnRows(emptyRows("id"))
+	replica.ExpectQuery("SELECT .* AS bucket, alert_type AS severity, COUNT\\(\\*\\) AS count FROM `redis_alerts`").
+		WillReturnRows(emptyRows("bucket", "severity", "count"))
+	r := gin.New()
+	r.GET("/api/alerts/:module", getAlerts)
+	if w := serveRequest(r, httptest.NewRequest(http.MethodGet, "/api/alerts/redis", nil)); w.Code != http.StatusOK {
+		t.Fatalf("getAlerts status = %d: %s", w.Code, w.Body.String())
+	}
+
+	// A dbresolver.Write lookup goes to the primary even though it is a read
+	primary.ExpectQuery("SELECT `id` FROM `redis_alerts`").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	record := &RedisAlert{Alert: Alert{Module: "redis", EventName: "big_keys", HostIP: "10.0.0.1", Timestamp: time.Now()}}
+	id, err := existingAlertID(record)
+	if err != nil || id != 7 {
+		t.Fatalf("existingAlertID = %d, %v; want 7", id, err)
+	}
+}
+
+func TestUTCTimestampsMarshalInUTC(t *testing.T) {
+	local := time.Date(2024, 5, 1, 20, 30, 0, 0, time.FixedZone("CST", 8*3600))
+	rows := []map[string]interface{}{{"id": 1, "timestamp": local}}
+	utcTimestamps(rows)
+
+	raw, err := json.Marshal(rows[0])
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if want := `"timestamp":"2024-05-01T12:30:00Z"`; !strings.Contains(string(raw), want) {
+		t.Errorf("row = %s, want it to contain %s", raw, want)
+	}
+}
+
+func TestGetAlertsBreaksTimestampTiesByID(t *testing.T) {
+	loadTestConfig(t, nil)
+	mock := mockDB(t)
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT \\* FROM `redis_alerts` WHERE .* ORDER BY timestamp desc, id desc LIMIT \\?").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "timestamp", "module", "event_name"}).
+			AddRow(9, ts, "redis", "big_keys").
+			AddRow(8, ts, "redis", "big_keys"))
+	mock.ExpectQuery("AS bucket").WillReturnRows(emptyRows("bucket", "severity", "count"))
+
+	r := gin.New()
+	r.GET("/api/alerts/:module", getAlerts)
+	w := serveRequest(r, httptest.NewRequest(http.MethodGet, "/api/alerts/redis?limit=2", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Alerts     []struct{ ID uint64 } `json:"alerts"`
+		NextCursor string                `json:"nextCursor"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Alerts) != 2 || resp.Alerts[0].ID != 9 || resp.Alerts[1].ID != 8 {
+		t.Fatalf("alerts = %+v, want ids 9, 8", resp.Alerts)
+	}
+
+	// The next page seeks strictly below the last (timestamp, id), so the tie is not repeated
+	cur, err := decodeCursor(resp.NextCursor)
+	if err != nil || !cur.Timestamp.Equal(ts) || cur.ID != 8 {
+		t.Errorf("nextCursor = %+v, %v; want (%v, 8)", cur, err, ts)
+	}
+}
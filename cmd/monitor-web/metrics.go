@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// prometheusContentType is the Prometheus text exposition format content type
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// labelledCount is an alert count for one event name and severity
+type labelledCount struct {
+	EventName string
+	AlertType string
+	Count     int64
+}
+
+// getAlertMetrics godoc
+// @Summary Get alert statistics as Prometheus metrics
+// @Description Exposes a module's alert counts in the Prometheus text format for direct scraping: alerts received in the trailing window and alerts still open, each by event_name and severity (alert_type).
+// @Tags alerts
+// @Produce plain
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param window query string false "Trailing window for the recent count (e.g., 5m, 1h, 1d)" default(5m)
+// @Success 200 {string} string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/metrics [get]
+func getAlertMetrics(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+
+	windowParam := c.DefaultQuery("window", "5m")
+	window, err := parseDuration(windowParam)
+	if err != nil || window <= 0 {
+		slog.Warn("Invalid metrics window", "window", windowParam, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid window"})
+		return
+	}
+
+	countBy := func(where string, args ...interface{}) ([]labelledCount, error) {
+		var counts []labelledCount
+		err := db.Table(tableName).
+			Select("event_name, alert_type, COUNT(*) AS count").
+			Where(where, args...).
+			Group("event_name, alert_type").
+			Order("event_name, alert_type").
+			Scan(&counts).Error
+		return counts, err
+	}
+	recent, err := countBy("timestamp >= ?", time.Now().Add(-window))
+	if err != nil {
+		slog.Error("Failed to count recent alerts", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+	open, err := countBy("status <> ?", statusResolved)
+	if err != nil {
+		slog.Error("Failed to count open alerts", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+
+	var b strings.Builder
+	writeAlertGauge(&b, "monitor_web_alerts_recent", "Alerts received in the trailing window.", module, recent, `,window="`+promLabelValue(windowParam)+`"`)
+	writeAlertGauge(&b, "monitor_web_alerts_open", "Alerts not yet resolved.", module, open, "")
+	c.Data(http.StatusOK, prometheusContentType, []byte(b.String()))
+}
+
+// writeAlertGauge appends one gauge family with a sample per event name and severity
+func writeAlertGauge(b *strings.Builder, name, help, module string, counts []labelledCount, extraLabels string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	for _, cnt := range counts {
+		fmt.Fprintf(b, "%s{module=\"%s\",event_name=\"%s\",severity=\"%s\"%s} %d\n",
+			name, promLabelValue(module), promLabelValue(cnt.EventName), promLabelValue(cnt.AlertType), extraLabels, cnt.Count)
+	}
+}
+
+// promLabelValue escapes s for use inside a quoted Prometheus label value
+func promLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ddlRecorder is a gorm logger that captures the SQL of every traced statement
+type ddlRecorder struct {
+	logger.Interface
+	statements []string
+}
+
+func (r *ddlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
+// planMigration returns the DDL that migrating models would run, without executing it.
+// Existence checks run against the live schema; the DDL itself is rendered by a dry-run
+// session. Only additive changes are planned: missing tables, columns and indexes
+// (including the ENFORCE_UNIQUE and ENABLE_FULLTEXT indexes). Column type changes are not detected.
+func planMigration(models ...interface{}) ([]string, error) {
+	recorder := &ddlRecorder{Interface: logger.Discard}
+	drySession := db.Session(&gorm.Session{DryRun: true, Logger: recorder})
+	dry := drySession.Migrator()
+	live := db.Migrator()
+
+	for _, model := range models {
+		if !live.HasTable(model) {
+			if err := withTableOptions(drySession, model).Migrator().CreateTable(model); err != nil {
+				return nil, err
+			}
+			if viper.GetBool("ENFORCE_UNIQUE") && isAlertModel(model) {
+				ddl, err := uniqueIndexDDL(model)
+				if err != nil {
+					return nil, err
+				}
+				recorder.statements = append(recorder.statements, ddl)
+			}
+			if viper.GetBool("ENABLE_FULLTEXT") && isAlertModel(model) {
+				ddl, err := fulltextIndexDDL(model)
+				if err != nil {
+					return nil, err
+				}
+				recorder.statements = append(recorder.statements, ddl)
+			}
+			continue
+		}
+
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model: %w", err)
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || field.IgnoreMigration || live.HasColumn(model, field.DBName) {
+				continue
+			}
+			if err := dry.AddColumn(model, field.DBName); err != nil {
+				return nil, err
+			}
+		}
+
+		indexes := stmt.Schema.ParseIndexes()
+		names := make([]string, 0, len(indexes))
+		for name := range indexes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if live.HasIndex(model, name) {
+				continue
+			}
+			if err := dry.CreateIndex(model, name); err != nil {
+				return nil, err
+			}
+		}
+		if viper.GetBool("ENFORCE_UNIQUE") && isAlertModel(model) && !live.HasIndex(model, uniqueIndexName) {
+			ddl, err := uniqueIndexDDL(model)
+			if err != nil {
+				return nil, err
+			}
+			recorder.statements = append(recorder.statements, ddl)
+		}
+		if viper.GetBool("ENABLE_FULLTEXT") && isAlertModel(model) && !live.HasIndex(model, fulltextIndexName) {
+			ddl, err := fulltextIndexDDL(model)
+			if err != nil {
+				return nil, err
+			}
+			recorder.statements = append(recorder.statements, ddl)
+		}
+	}
+	return recorder.statements, nil
+}
+
+// isAlertModel reports whether model is one of the alerts table models
+func isAlertModel(model interface{}) bool {
+	for _, m := range alertModels {
+		if m == model {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+)
+
+// foreignModuleFields returns the JSON names of event's populated module-specific fields
+// that belong to a module other than event.Module. The insert drops them, so they usually
+// mean the agent built the payload from the wrong module's template. Ingest lists them in
+// the response's ignoredFields, or rejects the event under STRICT_SCHEMA.
+func foreignModuleFields(event *AlertEvent) []string {
+	v := reflect.ValueOf(event.AlertModuleData)
+	t := v.Type()
+	var foreign []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if module := f.Tag.Get("module"); module != "" && module != event.Module && !v.Field(i).IsNil() {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			foreign = append(foreign, name)
+		}
+	}
+	return foreign
+}
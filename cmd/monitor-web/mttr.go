@@ -0,0 +1,93 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// resolveSecondsExpr is the time from firing to resolution of one alert, in seconds
+const resolveSecondsExpr = "TIMESTAMPDIFF(SECOND, timestamp, resolved_at)"
+
+// mttrStats is the resolution-time summary of the resolved alerts in range
+type mttrStats struct {
+	Resolved    int64
+	MeanSeconds *float64
+}
+
+// mttrMedian is the median resolution time, scanned on its own from the ranked durations
+type mttrMedian struct {
+	MedianSeconds *float64
+}
+
+// getMTTR godoc
+// @Summary Get the mean time to resolve for a module
+// @Description Returns the mean and median time from firing to resolution, in seconds, of resolved alerts in the range, plus how many alerts in the range are still open. Mean and median are null when nothing was resolved.
+// @Tags alerts
+// @Produce json
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param from query string false "Start date (YYYY-MM-DD); defaults to now minus DEFAULT_RANGE when from and to are omitted"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param alert_type query string false "Alert type filter"
+// @Param q query string false "Search text in details (substring match, or full-text match with ENABLE_FULLTEXT)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/mttr [get]
+func getMTTR(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+
+	resolved := func() *gorm.DB {
+		query, _ := applyAlertFilters(c, db.Table(tableName).Where("status = ? AND resolved_at IS NOT NULL", statusResolved))
+		return query
+	}
+
+	var stats mttrStats
+	if err := resolved().Select("COUNT(*) AS resolved, AVG(" + resolveSecondsExpr + ") AS mean_seconds").Scan(&stats).Error; err != nil {
+		slog.Error("Failed to compute MTTR", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+
+	// The median averages the middle one or two durations, ranked with window functions
+	var median mttrMedian
+	ranked := resolved().Select(resolveSecondsExpr + " AS seconds, " +
+		"ROW_NUMBER() OVER (ORDER BY " + resolveSecondsExpr + ") AS seconds_rank, COUNT(*) OVER () AS total")
+	err := db.Table("(?) AS ranked", ranked).
+		Select("AVG(seconds) AS median_seconds").
+		Where("seconds_rank IN (FLOOR((total + 1) / 2), CEIL((total + 1) / 2))").
+		Scan(&median).Error
+	if err != nil {
+		slog.Error("Failed to compute median time to resolve", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+
+	var open int64
+	openQuery, appliedRange := applyAlertFilters(c, db.Table(tableName).Where("status <> ?", statusResolved))
+	if err := openQuery.Count(&open).Error; err != nil {
+		slog.Error("Failed to count open alerts", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+
+	resp := gin.H{
+		"module":        module,
+		"resolved":      stats.Resolved,
+		"open":          open,
+		"meanSeconds":   stats.MeanSeconds,
+		"medianSeconds": median.MedianSeconds,
+	}
+	if appliedRange != nil {
+		resp["defaultRange"] = appliedRange
+	}
+	respondJSON(c, http.StatusOK, resp)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+)
+
+// HostMute suppresses alerts from a host across all modules until it expires
+type HostMute struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	HostIP    string    `gorm:"uniqueIndex;not null;size:50" json:"host_ip"`
+	Until     time.Time `gorm:"index;not null" json:"until"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// utc converts the mute's timestamps to UTC for API responses
+func (m *HostMute) utc() {
+	m.Until = m.Until.UTC()
+	m.CreatedAt = m.CreatedAt.UTC()
+}
+
+// isHostMuted reports whether any of hosts has an active mute
+func isHostMuted(hosts []string) (bool, error) {
+	if len(hosts) == 0 {
+		return false, nil
+	}
+	var count int64
+	err := db.Model(&HostMute{}).Where("host_ip IN ? AND until > ?", hosts, time.Now()).Count(&count).Error
+	return count > 0, err
+}
+
+// muteHost godoc
+// @Summary Mute a host
+// @Description Suppresses alerts from the host across all modules for the given duration. Matching alerts are still stored but flagged as suppressed.
+// @Tags hosts
+// @Produce json
+// @Param host_ip path string true "Host IP"
+// @Param duration query string false "Mute duration (e.g., 30m, 1h, 1d)" default(1h)
+// @Success 200 {object} HostMute
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{host_ip}/mute [post]
+func muteHost(c *gin.Context) {
+	hostIP := c.Param("host_ip")
+	durationParam := c.DefaultQuery("duration", "1h")
+	duration, err := parseDuration(durationParam)
+	if err != nil || duration <= 0 {
+		slog.Warn("Invalid mute duration", "duration", durationParam, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid duration"})
+		return
+	}
+
+	mute := HostMute{HostIP: hostIP, Until: time.Now().Add(duration)}
+	err = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "host_ip"}},
+		DoUpdates: clause.AssignmentColumns([]string{"until"}),
+	}).Create(&mute).Error
+	if err != nil {
+		slog.Error("Failed to mute host", "host_ip", hostIP, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to mute host"})
+		return
+	}
+	slog.Info("Muted host", "host_ip", hostIP, "until", mute.Until, "client_ip", c.ClientIP(), "component", "monitor-web")
+	mute.utc()
+	respondJSON(c, http.StatusOK, mute)
+}
+
+// unmuteHost godoc
+// @Summary Unmute a host
+// @Description Removes the host's mute so its alerts are no longer suppressed.
+// @Tags hosts
+// @Produce json
+// @Param host_ip path string true "Host IP"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{host_ip}/mute [delete]
+func unmuteHost(c *gin.Context) {
+	hostIP := c.Param("host_ip")
+	result := db.Where("host_ip = ?", hostIP).Delete(&HostMute{})
+	if result.Error != nil {
+		slog.Error("Failed to unmute host", "host_ip", hostIP, "error", result.Error, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to unmute host"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		respondJSON(c, http.StatusNotFound, gin.H{"error": "Host is not muted"})
+		return
+	}
+	slog.Info("Unmuted host", "host_ip", hostIP, "client_ip", c.ClientIP(), "component", "monitor-web")
+	respondJSON(c, http.StatusOK, gin.H{"status": "unmuted"})
+}
+
+// listMutedHosts godoc
+// @Summary List muted hosts
+// @Description Returns the hosts whose mute has not yet expired.
+// @Tags hosts
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /hosts/muted [get]
+func listMutedHosts(c *gin.Context) {
+	mutes := []HostMute{}
+	if err := db.Where("until > ?", time.Now()).Order("until asc").Find(&mutes).Error; err != nil {
+		slog.Error("Failed to list muted hosts", "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to list muted hosts"})
+		return
+	}
+	for i := range mutes {
+		mutes[i].utc()
+	}
+	respondJSON(c, http.StatusOK, gin.H{"hosts": mutes})
+}
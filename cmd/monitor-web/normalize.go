@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// lowercaseFields are the NORMALIZE_FIELDS lowercased at ingest
+var lowercaseFields map[string]bool
+
+var (
+	// alertTypes maps each lowercased ALERT_TYPES or ALERT_TYPE_ALIASES name to its
+	// canonical alert_type
+	alertTypes map[string]string
+	// defaultAlertType is the ALERT_TYPE_DEFAULT stored for unrecognised alert_type
+	// values ("" keeps them as sent)
+	defaultAlertType string
+)
+
+// identityFields returns pointers to the event's grouping fields by JSON name
+func identityFields(event *AlertEvent) map[string]*string {
+	return map[string]*string{
+		"module":       &event.Module,
+		"service_name": &event.ServiceName,
+		"event_name":   &event.EventName,
+		"host_ip":      &event.HostIP,
+		"alert_type":   &event.AlertType,
+		"cluster_name": &event.ClusterName,
+		"hostname":     &event.Hostname,
+	}
+}
+
+// initNormalization parses NORMALIZE_FIELDS, a comma-separated list of identity fields
+// to lowercase at ingest
+func initNormalization() error {
+	known := identityFields(&AlertEvent{})
+	lowercaseFields = make(map[string]bool)
+	for _, field := range splitList(viper.GetString("NORMALIZE_FIELDS")) {
+		if _, ok := known[field]; !ok {
+			names := make([]string, 0, len(known))
+			for name := range known {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("invalid MONITOR_WEB_NORMALIZE_FIELDS entry %q: want one of %s", field, strings.Join(names, ", "))
+		}
+		lowercaseFields[field] = true
+	}
+	return nil
+}
+
+// initAlertTypes parses ALERT_TYPES, the canonical alert_type values, ALERT_TYPE_ALIASES,
+// comma-separated synonym=canonical pairs, and ALERT_TYPE_DEFAULT
+func initAlertTypes() error {
+	alertTypes = make(map[string]string)
+	for _, canonical := range splitList(viper.GetString("ALERT_TYPES")) {
+		alertTypes[strings.ToLower(canonical)] = canonical
+	}
+	for _, pair := range splitList(viper.GetString("ALERT_TYPE_ALIASES")) {
+		alias, canonical, ok := strings.Cut(pair, "=")
+		alias, canonical = strings.TrimSpace(alias), strings.TrimSpace(canonical)
+		if !ok || alias == "" || canonical == "" {
+			return fmt.Errorf("invalid MONITOR_WEB_ALERT_TYPE_ALIASES entry %q: want synonym=canonical", pair)
+		}
+		if alertTypes[strings.ToLower(canonical)] != canonical {
+			return fmt.Errorf("invalid MONITOR_WEB_ALERT_TYPE_ALIASES entry %q: %q is not in MONITOR_WEB_ALERT_TYPES", pair, canonical)
+		}
+		alertTypes[strings.ToLower(alias)] = canonical
+	}
+	defaultAlertType = viper.GetString("ALERT_TYPE_DEFAULT")
+	if defaultAlertType != "" && alertTypes[strings.ToLower(defaultAlertType)] != defaultAlertType {
+		return fmt.Errorf("invalid MONITOR_WEB_ALERT_TYPE_DEFAULT %q: not in MONITOR_WEB_ALERT_TYPES", defaultAlertType)
+	}
+	return nil
+}
+
+// normalizeAlertType folds raw, case-insensitively, onto its canonical alert_type, so
+// "CRITICAL" and "crit" are both stored and filtered as "critical". Unrecognised values
+// become ALERT_TYPE_DEFAULT when it is set; an empty alert_type stays empty.
+func normalizeAlertType(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	if canonical, ok := alertTypes[strings.ToLower(raw)]; ok {
+		return canonical
+	}
+	if defaultAlertType != "" {
+		return defaultAlertType
+	}
+	return raw
+}
+
+// normalizeEvent trims whitespace from the identity fields, and lowercases those listed
+// in NORMALIZE_FIELDS, so " Redis " and "redis" group together. admitEvent runs it once
+// per event, before the ingest key is looked up by module.
+func normalizeEvent(event *AlertEvent) {
+	for name, field := range identityFields(event) {
+		*field = strings.TrimSpace(*field)
+		if lowercaseFields[name] {
+			*field = strings.ToLower(*field)
+		}
+	}
+	for i, ip := range event.HostIPs {
+		event.HostIPs[i] = strings.TrimSpace(ip)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestNormalizeEventCollapsesCaseAndWhitespace(t *testing.T) {
+	loadTestConfig(t, map[string]string{"NORMALIZE_FIELDS": "service_name"})
+	mock := mockDB(t)
+	ts := time.Now()
+
+	padded, plain := testEvent(ts), testEvent(ts)
+	padded.ServiceName, plain.ServiceName = " Redis ", "redis"
+	padded.Module = " redis "
+	var authorized string
+	var fingerprints []string
+	for _, event := range []*AlertEvent{padded, plain} {
+		mock.ExpectQuery("FROM `host_mutes`").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		record, err := admitEvent(event, "192.0.2.1", func(module string) error {
+			authorized = module
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("admitEvent: %v", err)
+		}
+		if authorized != "redis" {
+			t.Errorf("ingest key checked for module %q, want redis", authorized)
+		}
+		fingerprints = append(fingerprints, record.base().Fingerprint)
+	}
+	if padded.ServiceName != "redis" {
+		t.Errorf("service_name = %q, want redis", padded.ServiceName)
+	}
+	if fingerprints[0] != fingerprints[1] {
+		t.Errorf("fingerprints differ: %s vs %s", fingerprints[0], fingerprints[1])
+	}
+}
+
+func TestNormalizeEventOnlyTrimsByDefault(t *testing.T) {
+	loadTestConfig(t, nil)
+	event := testEvent(time.Now())
+	event.ServiceName = " Redis "
+	normalizeEvent(event)
+	if event.ServiceName != "Redis" {
+		t.Errorf("service_name = %q, want Redis", event.ServiceName)
+	}
+}
+
+func TestNormalizeAlertType(t *testing.T) {
+	tests := []struct {
+		name, defaultType, raw, want string
+	}{
+		{"upper case", "", "CRITICAL", "critical"},
+		{"synonym", "", "crit", "critical"},
+		{"canonical", "", "critical", "critical"},
+		{"padded synonym", "", " Warn ", "warning"},
+		{"empty", "info", "", ""},
+		{"unknown kept", "", "sev1", "sev1"},
+		{"unknown defaulted", "info", "sev1", "info"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadTestConfig(t, map[string]string{"ALERT_TYPE_DEFAULT": tt.defaultType})
+			if got := normalizeAlertType(tt.raw); got != tt.want {
+				t.Errorf("normalizeAlertType(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+
+			// The stored record keeps what the agent sent alongside the canonical value
+			event := testEvent(time.Now())
+			event.AlertType = tt.raw
+			alert := buildRecord(event, false).base()
+			if alert.AlertType != tt.want || alert.RawAlertType != tt.raw {
+				t.Errorf("record alert_type = %q, raw_alert_type = %q; want %q, %q", alert.AlertType, alert.RawAlertType, tt.want, tt.raw)
+			}
+		})
+	}
+}
+
+func TestBuildRecordKeepsDerivedSeverityOutOfRaw(t *testing.T) {
+	loadTestConfig(t, map[string]string{"SEVERITY_RULES_REDIS": "critical: big_keys_count > 100"})
+	event := testEvent(time.Now())
+	count := 500
+	event.BigKeysCount = &count
+	alert := buildRecord(event, false).base()
+	if alert.AlertType != "critical" || alert.RawAlertType != "" || event.AlertType != "" {
+		t.Errorf("record alert_type = %q, raw_alert_type = %q, event alert_type = %q; want critical, empty, empty",
+			alert.AlertType, alert.RawAlertType, event.AlertType)
+	}
+}
+
+func TestInitAlertTypesRejectsUnknownCanonical(t *testing.T) {
+	loadTestConfig(t, nil)
+	for key, value := range map[string]string{
+		"MONITOR_WEB_ALERT_TYPE_ALIASES": "sev1=page",
+		"MONITOR_WEB_ALERT_TYPE_DEFAULT": "page",
+	} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, value)
+			if err := initAlertTypes(); err == nil {
+				t.Errorf("initAlertTypes accepted %s=%s", key, value)
+			}
+		})
+	}
+}
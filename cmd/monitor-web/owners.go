@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/spf13/viper"
+)
+
+// serviceOwners is the service_name to owning team catalog loaded from SERVICE_OWNERS;
+// it is swapped atomically on reload so ingest never sees a half-loaded map
+var serviceOwners atomic.Pointer[map[string]string]
+
+// loadServiceOwners reads SERVICE_OWNERS, a JSON object mapping service names to teams.
+// Without SERVICE_OWNERS every alert gets an empty owner.
+func loadServiceOwners() error {
+	owners := map[string]string{}
+	if path := viper.GetString("SERVICE_OWNERS"); path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read MONITOR_WEB_SERVICE_OWNERS: %w", err)
+		}
+		if err := json.Unmarshal(raw, &owners); err != nil {
+			return fmt.Errorf("invalid MONITOR_WEB_SERVICE_OWNERS file %s: %w", path, err)
+		}
+	}
+	serviceOwners.Store(&owners)
+	return nil
+}
+
+// ownerTeam returns the team owning service, or "" when the catalog does not list it
+func ownerTeam(service string) string {
+	if owners := serviceOwners.Load(); owners != nil {
+		return (*owners)[service]
+	}
+	return ""
+}
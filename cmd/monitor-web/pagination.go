@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// defaultPageSize is used when a paginated request omits page_size
+const defaultPageSize = 50
+
+var (
+	errInvalidPage     = errors.New("invalid page")
+	errInvalidPageSize = errors.New("invalid page_size")
+	errInvalidCursor   = errors.New("invalid cursor")
+	errInvalidLimit    = errors.New("invalid limit")
+)
+
+// parsePagination reads the 1-based page and page_size query parameters. page_size must be
+// positive and is clamped to MAX_PAGE_SIZE so a single request cannot load unbounded rows.
+func parsePagination(c *gin.Context) (page, pageSize int, err error) {
+	page, err = strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		return 0, 0, errInvalidPage
+	}
+	pageSize, err = strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(defaultPageSize)))
+	if err != nil || pageSize < 1 {
+		return 0, 0, errInvalidPageSize
+	}
+	if max := viper.GetInt("MAX_PAGE_SIZE"); pageSize > max {
+		pageSize = max
+	}
+	return page, pageSize, nil
+}
+
+// parseLimit reads the limit query parameter, defaulting to def. It must be positive and is
+// clamped to MAX_PAGE_SIZE like page_size.
+func parseLimit(c *gin.Context, def int) (int, error) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(def)))
+	if err != nil || limit < 1 {
+		return 0, errInvalidLimit
+	}
+	if max := viper.GetInt("MAX_PAGE_SIZE"); limit > max {
+		limit = max
+	}
+	return limit, nil
+}
+
+// paginationErrorMessage is the client-facing message for a parsePagination error
+func paginationErrorMessage(err error) string {
+	if errors.Is(err, errInvalidPageSize) {
+		return "Invalid page_size"
+	}
+	return "Invalid page"
+}
+
+// alertCursor is the keyset position of the last row a client has seen: rows are ordered
+// by timestamp desc, id desc, so the next page starts strictly below it
+type alertCursor struct {
+	Timestamp time.Time `json:"ts"`
+	ID        uint64    `json:"id"`
+}
+
+// encodeCursor returns the opaque cursor string for the row at (ts, id)
+func encodeCursor(ts time.Time, id uint64) string {
+	raw, _ := json.Marshal(alertCursor{Timestamp: ts.UTC(), ID: id}) // plain struct always marshals
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor parses a cursor produced by encodeCursor
+func decodeCursor(s string) (alertCursor, error) {
+	var cur alertCursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(raw, &cur) != nil || cur.Timestamp.IsZero() {
+		return alertCursor{}, errInvalidCursor
+	}
+	return cur, nil
+}
+
+// rowID returns the id column of a map-scanned row, whatever integer type the driver chose
+func rowID(row map[string]interface{}) (uint64, bool) {
+	var idText string
+	switch id := row["id"].(type) {
+	case []byte:
+		idText = string(id)
+	default:
+		idText = fmt.Sprint(id)
+	}
+	id, err := strconv.ParseUint(idText, 10, 64)
+	return id, err == nil
+}
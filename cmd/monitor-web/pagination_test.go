@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// queryContext is a gin context for a GET request with the given query string
+func queryContext(query string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/alerts/redis?"+query, nil)
+	return c
+}
+
+func TestParsePagination(t *testing.T) {
+	loadTestConfig(t, map[string]string{"MAX_PAGE_SIZE": "100"})
+	tests := []struct {
+		query    string
+		page     int
+		pageSize int
+		err      error
+	}{
+		{"", 1, defaultPageSize, nil},
+		{"page=0", 0, 0, errInvalidPage},
+		{"page=-2", 0, 0, errInvalidPage},
+		{"page=first", 0, 0, errInvalidPage},
+		{"page=2&page_size=0", 0, 0, errInvalidPageSize},
+		{"page=2&page_size=-5", 0, 0, errInvalidPageSize},
+		{"page=2&page_size=ten", 0, 0, errInvalidPageSize},
+		{"page=2&page_size=100", 2, 100, nil},
+		{"page=2&page_size=101", 2, 100, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			page, pageSize, err := parsePagination(queryContext(tt.query))
+			if !errors.Is(err, tt.err) || page != tt.page || pageSize != tt.pageSize {
+				t.Errorf("parsePagination(%q) = %d, %d, %v; want %d, %d, %v", tt.query, page, pageSize, err, tt.page, tt.pageSize, tt.err)
+			}
+		})
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	loadTestConfig(t, map[string]string{"MAX_PAGE_SIZE": "100"})
+	tests := []struct {
+		query string
+		limit int
+		err   error
+	}{
+		{"", 20, nil},
+		{"limit=0", 0, errInvalidLimit},
+		{"limit=-1", 0, errInvalidLimit},
+		{"limit=all", 0, errInvalidLimit},
+		{"limit=100", 100, nil},
+		{"limit=5000", 100, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			limit, err := parseLimit(queryContext(tt.query), 20)
+			if !errors.Is(err, tt.err) || limit != tt.limit {
+				t.Errorf("parseLimit(%q) = %d, %v; want %d, %v", tt.query, limit, err, tt.limit, tt.err)
+			}
+		})
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 20, 30, 15, 123456789, time.FixedZone("CST", 8*3600))
+	cur, err := decodeCursor(encodeCursor(ts, 42))
+	if err != nil {
+		t.Fatalf("decodeCursor: %v", err)
+	}
+	if !cur.Timestamp.Equal(ts) || cur.Timestamp.Location() != time.UTC || cur.ID != 42 {
+		t.Errorf("cursor = %v (%s), %d; want %v in UTC, 42", cur.Timestamp, cur.Timestamp.Location(), cur.ID, ts)
+	}
+}
+
+func TestDecodeCursorRejectsInvalid(t *testing.T) {
+	for name, raw := range map[string]string{
+		"not base64":     "%%%",
+		"not JSON":       base64.RawURLEncoding.EncodeToString([]byte("id=42")),
+		"zero timestamp": encodeCursor(time.Time{}, 42),
+	} {
+		if _, err := decodeCursor(raw); !errors.Is(err, errInvalidCursor) {
+			t.Errorf("%s: decodeCursor(%q) error = %v, want errInvalidCursor", name, raw, err)
+		}
+	}
+}
+
+func TestRowID(t *testing.T) {
+	for _, id := range []interface{}{[]byte("42"), int64(42), uint64(42), "42"} {
+		if got, ok := rowID(map[string]interface{}{"id": id}); !ok || got != 42 {
+			t.Errorf("rowID(%T %v) = %d, %v; want 42", id, id, got, ok)
+		}
+	}
+	if _, ok := rowID(map[string]interface{}{}); ok {
+		t.Error("rowID of a row without id reported ok")
+	}
+}
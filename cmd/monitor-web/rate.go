@@ -0,0 +1,52 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getAlertRate godoc
+// @Summary Get the alert rate for a module
+// @Description Counts alerts in the trailing window and returns the per-minute rate, for real-time rate gauges.
+// @Tags alerts
+// @Produce json
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param window query string false "Trailing window (e.g., 5m, 1h, 1d)" default(5m)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/rate [get]
+func getAlertRate(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+
+	windowParam := c.DefaultQuery("window", "5m")
+	window, err := parseDuration(windowParam)
+	if err != nil || window <= 0 {
+		slog.Warn("Invalid rate window", "window", windowParam, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid window"})
+		return
+	}
+
+	var count int64
+	if err := db.Table(tableName).Where("timestamp >= ?", time.Now().Add(-window)).Count(&count).Error; err != nil {
+		slog.Error("Failed to count alerts", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"module":    module,
+		"window":    windowParam,
+		"count":     count,
+		"perMinute": float64(count) / window.Minutes(),
+	})
+}
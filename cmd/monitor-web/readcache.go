@@ -0,0 +1,78 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxReadCacheEntries bounds the read cache; new results are not cached while it is full
+const maxReadCacheEntries = 1000
+
+// readCacheTTL is how long a successful read stays servable as stale (0 disables caching)
+var readCacheTTL time.Duration
+
+// readCacheEntry is the last successful response to one request URL
+type readCacheEntry struct {
+	resp     gin.H
+	cachedAt time.Time
+}
+
+var (
+	readCacheMu sync.Mutex
+	readCache   = make(map[string]readCacheEntry)
+)
+
+// readCacheKey identifies a read by its path and query, so each filter combination is cached apart
+func readCacheKey(c *gin.Context) string {
+	return c.Request.URL.Path + "?" + c.Request.URL.RawQuery
+}
+
+// cacheRead remembers resp as the last successful response to this request
+func cacheRead(c *gin.Context, resp gin.H) {
+	if readCacheTTL <= 0 {
+		return
+	}
+	now := time.Now()
+	readCacheMu.Lock()
+	defer readCacheMu.Unlock()
+	if len(readCache) >= maxReadCacheEntries {
+		for key, entry := range readCache {
+			if now.Sub(entry.cachedAt) > readCacheTTL {
+				delete(readCache, key)
+			}
+		}
+	}
+	key := readCacheKey(c)
+	if _, ok := readCache[key]; !ok && len(readCache) >= maxReadCacheEntries {
+		return
+	}
+	readCache[key] = readCacheEntry{resp: resp, cachedAt: now}
+}
+
+// serveStaleRead answers a read whose query failed with its last successful response,
+// flagged stale, if that is younger than READ_CACHE_TTL. It reports whether it responded.
+func serveStaleRead(c *gin.Context) bool {
+	if readCacheTTL <= 0 {
+		return false
+	}
+	readCacheMu.Lock()
+	entry, ok := readCache[readCacheKey(c)]
+	readCacheMu.Unlock()
+	if !ok || time.Since(entry.cachedAt) > readCacheTTL {
+		return false
+	}
+
+	resp := make(gin.H, len(entry.resp)+2)
+	for k, v := range entry.resp {
+		resp[k] = v
+	}
+	resp["stale"] = true
+	resp["cachedAt"] = entry.cachedAt.UTC()
+	slog.Warn("Serving stale read after query failure", "path", c.Request.URL.Path, "cached_at", entry.cachedAt.UTC(), "component", "monitor-web")
+	respondJSON(c, http.StatusOK, resp)
+	return true
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"gorm.io/gorm"
+)
+
+// findTyped scans query's rows into T, one of the alerts table models, so timestamps are
+// real time.Time values and module fields keep their Go types
+func findTyped[T any](query *gorm.DB) ([]T, error) {
+	rows := []T{}
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// findRecords runs findTyped for model T and returns the rows through their shared
+// alertRecord view
+func findRecords[T any, P interface {
+	*T
+	alertRecord
+}](query *gorm.DB) ([]alertRecord, error) {
+	rows, err := findTyped[T](query)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]alertRecord, len(rows))
+	for i := range rows {
+		records[i] = P(&rows[i])
+	}
+	return records, nil
+}
+
+// findModuleAlerts scans query's rows into module's model: []RedisAlert, []MySQLAlert,
+// []HostAlert or []SystemAlert, or []Alert for general and the modules sharing its columns.
+// The records marshal to the same flat column objects as the map-based read paths.
+func findModuleAlerts(module string, query *gorm.DB) ([]alertRecord, error) {
+	switch module {
+	case "redis":
+		return findRecords[RedisAlert](query)
+	case "mysql":
+		return findRecords[MySQLAlert](query)
+	case "host":
+		return findRecords[HostAlert](query)
+	case "system":
+		return findRecords[SystemAlert](query)
+	default:
+		return findRecords[Alert](query)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// requestIDHeader carries the request ID in and out; an incoming value is reused
+	requestIDHeader = "X-Request-ID"
+	// requestIDKey stores the request ID in the gin context
+	requestIDKey = "request_id"
+)
+
+// assignRequestID tags every request with an ID, echoed in the response header, so client
+// reports can be matched to log lines
+func assignRequestID(c *gin.Context) {
+	id := c.GetHeader(requestIDHeader)
+	if id == "" || len(id) > 64 {
+		buf := make([]byte, 8)
+		_, _ = rand.Read(buf)
+		id = hex.EncodeToString(buf)
+	}
+	c.Set(requestIDKey, id)
+	c.Header(requestIDHeader, id)
+	c.Next()
+}
+
+// recoverJSON replaces gin's default recovery: a panicking handler is logged with its
+// stack through slog and answered with a JSON 500 instead of a plain-text one
+func recoverJSON(c *gin.Context) {
+	defer func() {
+		if p := recover(); p != nil {
+			id := c.GetString(requestIDKey)
+			slog.Error("Recovered from panic", "panic", p, "stack", string(debug.Stack()), "method", c.Request.Method, "path", c.Request.URL.Path, "request_id", id, "client_ip", c.ClientIP(), "component", "monitor-web")
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			respondJSON(c, http.StatusInternalServerError, gin.H{
+				"error":      "Internal server error",
+				"code":       "internal_error",
+				"request_id": id,
+			})
+			c.Abort()
+		}
+	}()
+	c.Next()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRecoverJSON(t *testing.T) {
+	r := gin.New()
+	r.Use(assignRequestID, recoverJSON)
+	r.GET("/boom", func(c *gin.Context) { panic("boom") })
+
+	w := serveRequest(r, httptest.NewRequest(http.MethodGet, "/boom", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	var resp map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("response is not JSON: %v: %s", err, w.Body.String())
+	}
+	id := w.Header().Get(requestIDHeader)
+	if resp["code"] != "internal_error" || id == "" || resp["request_id"] != id {
+		t.Errorf("response = %v, %s header = %q; want code internal_error and the request ID", resp, requestIDHeader, id)
+	}
+}
+
+func TestMiddlewareRejectionsUseEnvelope(t *testing.T) {
+	loadTestConfig(t, map[string]string{"RESPONSE_ENVELOPE": "true", "INGEST_ALLOWED_CIDRS": "10.0.0.0/8"})
+	busy := make(chan struct{})
+	t.Cleanup(func() { close(busy) })
+	r := gin.New()
+	r.Use(assignRequestID, recoverJSON)
+	r.GET("/boom", func(c *gin.Context) { panic("boom") })
+	r.GET("/ingest", requireIngestSource, func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	held := make(chan struct{})
+	r.GET("/busy", limitConcurrency(1), func(c *gin.Context) {
+		if c.Query("hold") != "" {
+			close(held)
+			<-busy
+		}
+		c.Status(http.StatusNoContent)
+	})
+	go serveRequest(r, httptest.NewRequest(http.MethodGet, "/busy?hold=1", nil))
+	<-held
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		{"/boom", http.StatusInternalServerError},
+		{"/ingest", http.StatusForbidden},
+		{"/busy", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := serveRequest(r, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.code {
+				t.Fatalf("status = %d, want %d", w.Code, tt.code)
+			}
+			var resp struct {
+				APIVersion int               `json:"api_version"`
+				Meta       map[string]string `json:"meta"`
+				Error      map[string]interface{}
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("response is not JSON: %v: %s", err, w.Body.String())
+			}
+			if resp.APIVersion != apiVersion || resp.Meta["request_id"] != w.Header().Get(requestIDHeader) || resp.Error["message"] == nil {
+				t.Errorf("response = %s, want the envelope", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/spf13/viper"
+)
+
+// redactionMarker replaces each REDACT_PATTERNS match in stored details
+const redactionMarker = "[REDACTED]"
+
+// redactPatterns are the compiled REDACT_PATTERNS; redaction is disabled when empty
+var redactPatterns []*regexp.Regexp
+
+// initRedaction compiles the whitespace-separated REDACT_PATTERNS regular expressions
+func initRedaction() error {
+	redactPatterns = nil
+	for _, p := range viper.GetStringSlice("REDACT_PATTERNS") {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Errorf("invalid MONITOR_WEB_REDACT_PATTERNS entry %q: %w", p, err)
+		}
+		redactPatterns = append(redactPatterns, re)
+	}
+	return nil
+}
+
+// redact replaces every match of the configured patterns in s and returns the number of replacements
+func redact(s string) (string, int) {
+	count := 0
+	for _, re := range redactPatterns {
+		s = re.ReplaceAllStringFunc(s, func(string) string {
+			count++
+			return redactionMarker
+		})
+	}
+	return s, count
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestRedact(t *testing.T) {
+	loadTestConfig(t, map[string]string{
+		"REDACT_PATTERNS": `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,} token=[A-Za-z0-9]+`,
+	})
+	tests := []struct {
+		name  string
+		in    string
+		want  string
+		count int
+	}{
+		{"email", "login failed for ops@example.com", "login failed for [REDACTED]", 1},
+		{"token", "GET /hook?token=abc123XYZ returned 500", "GET /hook?[REDACTED] returned 500", 1},
+		{"email and token", "a@b.io and c@d.org sent token=s3cr3t", "[REDACTED] and [REDACTED] sent [REDACTED]", 3},
+		{"nothing sensitive", "user admin@localhost rotated token= keys", "user admin@localhost rotated token= keys", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count := redact(tt.in)
+			if got != tt.want || count != tt.count {
+				t.Errorf("redact(%q) = %q, %d; want %q, %d", tt.in, got, count, tt.want, tt.count)
+			}
+		})
+	}
+}
+
+func TestInitRedactionRejectsInvalidPattern(t *testing.T) {
+	loadTestConfig(t, nil)
+	t.Setenv("MONITOR_WEB_REDACT_PATTERNS", "token=[")
+	if err := initRedaction(); err == nil {
+		t.Error("initRedaction accepted an invalid pattern")
+	}
+	t.Setenv("MONITOR_WEB_REDACT_PATTERNS", "")
+	if err := initRedaction(); err != nil {
+		t.Errorf("initRedaction: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// catalog is a lookup table loaded at startup that SIGHUP reloads in place
+type catalog struct {
+	name string       // for logs
+	load func() error // replaces the catalog, leaving it untouched on error
+	size func() int   // entries currently loaded
+}
+
+// reloadOnSIGHUP reloads every catalog whenever the process receives SIGHUP. A catalog
+// whose source is unreadable or invalid keeps its previous contents; the others still reload.
+func reloadOnSIGHUP(catalogs ...catalog) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			for _, cat := range catalogs {
+				if err := cat.load(); err != nil {
+					slog.Error("Failed to reload catalog; keeping previous contents", "catalog", cat.name, "error", err, "component", "monitor-web")
+					continue
+				}
+				slog.Info("Reloaded catalog", "catalog", cat.name, "entries", cat.size(), "component", "monitor-web")
+			}
+		}
+	}()
+}
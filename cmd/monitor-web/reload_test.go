@@ -0,0 +1,26 @@
+package main
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReloadOnSIGHUPReloadsEveryCatalog(t *testing.T) {
+	failed, reloaded := make(chan struct{}, 1), make(chan struct{}, 1)
+	reloadOnSIGHUP(
+		catalog{"broken", func() error { failed <- struct{}{}; return errors.New("unreadable") }, func() int { return 0 }},
+		catalog{"good", func() error { reloaded <- struct{}{}; return nil }, func() int { return 1 }},
+	)
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("kill: %v", err)
+	}
+	for name, ch := range map[string]chan struct{}{"broken": failed, "good": reloaded} {
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("catalog %s was not reloaded", name)
+		}
+	}
+}
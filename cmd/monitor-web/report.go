@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+)
+
+// reportTimeFormat renders report timestamps; they are always UTC
+const reportTimeFormat = "2006-01-02 15:04:05 UTC"
+
+// reportField is one labelled column of the reported alert
+type reportField struct {
+	Label string
+	Value string
+}
+
+// reportEvent is one entry of the report's timeline
+type reportEvent struct {
+	At     time.Time
+	Event  string
+	Detail string
+}
+
+// reportSibling is another alert of the same incident
+type reportSibling struct {
+	Module string
+	Alert
+}
+
+// incidentReport is the data the report template renders
+type incidentReport struct {
+	Module      string
+	Alert       *Alert
+	Fields      []reportField
+	Timeline    []reportEvent
+	Siblings    []reportSibling
+	GeneratedAt time.Time
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"utc": func(t time.Time) string { return t.UTC().Format(reportTimeFormat) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Incident report: {{.Alert.EventName}} ({{.Module}} #{{.Alert.ID}})</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.5em; margin-bottom: 0.2em; }
+h2 { font-size: 1.15em; margin-top: 1.8em; border-bottom: 1px solid #ddd; padding-bottom: 0.2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; vertical-align: top; padding: 0.3em 0.6em; border-bottom: 1px solid #eee; }
+th { width: 14em; color: #555; font-weight: 600; }
+pre { margin: 0; white-space: pre-wrap; word-break: break-word; font-family: inherit; }
+.meta { color: #666; }
+@media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>{{.Alert.EventName}}</h1>
+<p class="meta">{{.Module}} alert #{{.Alert.ID}} &middot; {{.Alert.AlertType}} &middot; {{.Alert.Status}} &middot; {{.Alert.HostIP}}</p>
+
+<h2>Details</h2>
+<pre>{{.Alert.Details}}</pre>
+
+<h2>Timeline</h2>
+<table>
+{{range .Timeline}}<tr><th>{{utc .At}}</th><td>{{.Event}}{{if .Detail}} &middot; {{.Detail}}{{end}}</td></tr>
+{{end}}</table>
+
+<h2>Related alerts</h2>
+{{if .Siblings}}<table>
+<tr><th>Time</th><th>Module</th><th>Event</th><th>Host</th><th>Status</th></tr>
+{{range .Siblings}}<tr><td>{{utc .Timestamp}}</td><td>{{.Module}} #{{.ID}}</td><td>{{.EventName}}</td><td>{{.HostIP}}</td><td>{{.Status}}</td></tr>
+{{end}}</table>
+{{else}}<p class="meta">{{if .Alert.IncidentID}}No other alerts share incident {{.Alert.IncidentID}}.{{else}}The alert is not part of a fanned-out incident.{{end}}</p>
+{{end}}
+<h2>All fields</h2>
+<table>
+{{range .Fields}}<tr><th>{{.Label}}</th><td><pre>{{.Value}}</pre></td></tr>
+{{end}}</table>
+
+<p class="meta">Generated by monitor-web at {{utc .GeneratedAt}}</p>
+</body>
+</html>
+`))
+
+// getAlertReport godoc
+// @Summary Download an incident report for one alert
+// @Description Renders a self-contained, printable HTML report of the alert: its details, a timeline of when it fired, was received, acknowledged (and by whom) and resolved, the other alerts of its incident (those sharing its incident_id in any module), and every stored column. Use the browser's print dialog to save it as PDF.
+// @Tags alerts
+// @Produce html
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param id path int true "Alert ID"
+// @Success 200 {string} string "HTML report"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/{id}/report [get]
+func getAlertReport(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return
+	}
+
+	records, err := findModuleAlerts(module, db.Table(tableName).Where("id = ?", id).Limit(1))
+	if err != nil {
+		slog.Error("Failed to query alert for report", "module", module, "id", id, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+	if len(records) == 0 {
+		respondJSON(c, http.StatusNotFound, gin.H{"error": "Alert not found"})
+		return
+	}
+	record := records[0]
+	alert := record.base()
+
+	var siblings []reportSibling
+	if alert.IncidentID != "" {
+		if siblings, err = incidentSiblings(tableName, alert); err != nil {
+			slog.Error("Failed to query incident alerts for report", "module", module, "id", id, "incident_id", alert.IncidentID, "error", err, "component", "monitor-web")
+			respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+			return
+		}
+	}
+
+	var buf bytes.Buffer
+	err = reportTemplate.Execute(&buf, incidentReport{
+		Module:      module,
+		Alert:       alert,
+		Fields:      reportFields(module, record),
+		Timeline:    alertTimeline(alert),
+		Siblings:    siblings,
+		GeneratedAt: time.Now(),
+	})
+	if err != nil {
+		slog.Error("Failed to render alert report", "module", module, "id", id, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to render report"})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s-alert-%d-report.html"`, module, id))
+	c.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// incidentSiblings returns the other alerts of alert, stored in tableName, that share its
+// incident across every module, oldest first
+func incidentSiblings(tableName string, alert *Alert) ([]reportSibling, error) {
+	var siblings []reportSibling
+	for _, m := range storedModules() {
+		table, _ := moduleTable(m)
+		query := db.Table(table).Where("incident_id = ?", alert.IncidentID)
+		if table == tableName {
+			query = query.Where("id <> ?", alert.ID)
+		}
+		rows, err := findTyped[Alert](query.Order("timestamp, id"))
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			siblings = append(siblings, reportSibling{Module: m, Alert: row})
+		}
+	}
+	sort.SliceStable(siblings, func(i, j int) bool { return siblings[i].Timestamp.Before(siblings[j].Timestamp) })
+	return siblings, nil
+}
+
+// alertTimeline lists what happened to alert, in order
+func alertTimeline(alert *Alert) []reportEvent {
+	timeline := []reportEvent{{At: alert.Timestamp, Event: "Fired", Detail: alert.AlertType}}
+	if !alert.CreatedAt.IsZero() {
+		timeline = append(timeline, reportEvent{At: alert.CreatedAt, Event: "Received"})
+	}
+	if alert.AckedAt != nil {
+		timeline = append(timeline, reportEvent{At: *alert.AckedAt, Event: "Acknowledged", Detail: alert.AckedBy})
+	}
+	if alert.ResolvedAt != nil {
+		timeline = append(timeline, reportEvent{At: *alert.ResolvedAt, Event: "Resolved"})
+	}
+	sort.SliceStable(timeline, func(i, j int) bool { return timeline[i].At.Before(timeline[j].At) })
+	return timeline
+}
+
+// reportFields renders every column of record in moduleColumns order
+func reportFields(module string, record alertRecord) []reportField {
+	values := map[string]interface{}{}
+	recordFields(reflect.ValueOf(record).Elem(), values)
+	var fields []reportField
+	for _, col := range moduleColumns(module) {
+		fields = append(fields, reportField{Label: col.Label, Value: reportValue(values[col.Key])})
+	}
+	return fields
+}
+
+// reportValue formats a column value for display
+func reportValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return val.Format(reportTimeFormat)
+	case *time.Time:
+		if val == nil {
+			return ""
+		}
+		return val.Format(reportTimeFormat)
+	case datatypes.JSON:
+		return string(val)
+	}
+	return fmt.Sprint(v)
+}
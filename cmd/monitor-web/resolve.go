@@ -0,0 +1,45 @@
+package main
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// Alert lifecycle states stored in Alert.Status
+const (
+	statusFiring   = "firing"
+	statusAcked    = "acked" // still open, but someone is on it
+	statusResolved = "resolved"
+)
+
+// whereFingerprint narrows query to alerts sharing a's fingerprint, by default the same
+// module, service, event, host and cluster (FINGERPRINT_FIELDS)
+func whereFingerprint(query *gorm.DB, a *Alert) *gorm.DB {
+	return query.Where("fingerprint = ?", a.Fingerprint)
+}
+
+// resolveAlert marks the most recent open alert matching record's fingerprint as resolved.
+// It reports the resolved alert's id, or found=false when there is no open match.
+func resolveAlert(record alertRecord) (id uint64, found bool, err error) {
+	// Match on the primary so an alert stored moments ago is visible
+	query := db.Clauses(dbresolver.Write).Model(record).Select("id").Where("status <> ?", statusResolved)
+	err = whereFingerprint(query, record.base()).
+		Order("timestamp desc, id desc").
+		Limit(1).
+		Scan(&id).Error
+	if err != nil || id == 0 {
+		return 0, false, err
+	}
+
+	now := time.Now()
+	err = db.Model(record).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":      statusResolved,
+		"resolved_at": now,
+	}).Error
+	if err == nil {
+		invalidateFilterCache(record.base().Module)
+	}
+	return id, err == nil, err
+}
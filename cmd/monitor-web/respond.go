@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// apiVersion is the response envelope version, bumped only on breaking changes to data;
+// fields are added to data without a bump, so clients must ignore unknown fields
+const apiVersion = 1
+
+// respondJSON writes obj as the JSON response, indented when the client asks with
+// ?pretty=true or, unless it opts out with ?pretty=false, when APP_ENV is dev.
+// With RESPONSE_ENVELOPE on, obj is wrapped in the versioned envelope.
+func respondJSON(c *gin.Context, code int, obj interface{}) {
+	if viper.GetBool("RESPONSE_ENVELOPE") {
+		obj = envelope(c, code, obj)
+	}
+	pretty := viper.GetString("APP_ENV") == "dev"
+	if v, err := strconv.ParseBool(c.Query("pretty")); err == nil {
+		pretty = v
+	}
+	if pretty {
+		c.IndentedJSON(code, obj)
+		return
+	}
+	c.JSON(code, obj)
+}
+
+// envelope wraps obj as {api_version, data, meta, error}: successful responses carry obj
+// as data, error responses carry it as error with its "error" message renamed to message
+func envelope(c *gin.Context, code int, obj interface{}) gin.H {
+	resp := gin.H{
+		"api_version": apiVersion,
+		"data":        nil,
+		"meta":        gin.H{"request_id": c.GetString(requestIDKey)},
+		"error":       nil,
+	}
+	if code < 400 {
+		resp["data"] = obj
+		return resp
+	}
+	if h, ok := obj.(gin.H); ok {
+		errBody := gin.H{}
+		for k, v := range h {
+			if k == "error" {
+				k = "message"
+			}
+			errBody[k] = v
+		}
+		obj = errBody
+	}
+	resp["error"] = obj
+	return resp
+}
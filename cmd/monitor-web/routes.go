@@ -0,0 +1,42 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// routeInfo is one registered API route
+type routeInfo struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// listRoutes godoc
+// @Summary List API routes
+// @Description Returns the registered API routes and their methods. Only available when EXPOSE_ROUTES is enabled.
+// @Tags meta
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /routes [get]
+func listRoutes(engine *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		routes := []routeInfo{}
+		for _, r := range engine.Routes() {
+			// Skip documentation/static routes; only the API surface is of interest
+			if !strings.HasPrefix(r.Path, apiBasePath+"/api/") {
+				continue
+			}
+			routes = append(routes, routeInfo{Method: r.Method, Path: r.Path})
+		}
+		sort.Slice(routes, func(i, j int) bool {
+			if routes[i].Path != routes[j].Path {
+				return routes[i].Path < routes[j].Path
+			}
+			return routes[i].Method < routes[j].Method
+		})
+		respondJSON(c, http.StatusOK, gin.H{"routes": routes})
+	}
+}
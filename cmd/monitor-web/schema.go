@@ -0,0 +1,116 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ingestSchemaVersion is the latest ingest payload version; every version up to it is accepted
+const ingestSchemaVersion = 2
+
+// jsonSchemaDialect is the JSON Schema draft the generated schemas follow
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// requiredEventFields are the fields prepareRecord rejects an event without
+var requiredEventFields = []string{"module", "service_name", "event_name"}
+
+// getSchema godoc
+// @Summary Get the ingest schema
+// @Description Returns the JSON Schema of the alert payloads monitor-web accepts, generated from the event types: the flat v1 event, the nested v2 event and which module-specific fields apply to each module. Consumers can diff it in CI to catch contract drift.
+// @Tags schema
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /schema [get]
+func getSchema(c *gin.Context) {
+	v1 := typeSchema(reflect.TypeOf(AlertEvent{}))
+	v1["required"] = requiredEventFields
+	v2 := typeSchema(reflect.TypeOf(AlertEventV2{}))
+	v2["required"] = []string{"common"}
+	v2["properties"].(map[string]interface{})["common"].(map[string]interface{})["required"] = requiredEventFields
+	for _, schema := range []map[string]interface{}{v1, v2} {
+		schema["$schema"] = jsonSchemaDialect
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"version":           ingestSchemaVersion,
+		"supportedVersions": []int{1, 2},
+		"schemas": gin.H{
+			"1": v1,
+			"2": v2,
+		},
+		"modules": moduleFields(reflect.TypeOf(AlertModuleData{})),
+	})
+}
+
+// typeSchema returns the JSON Schema describing values of t as encoding/json reads them
+func typeSchema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Struct:
+		props := make(map[string]interface{})
+		structProperties(t, props)
+		return map[string]interface{}{"type": "object", "properties": props}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structProperties adds the schema of each of t's JSON fields, including promoted ones, to props
+func structProperties(t reflect.Type, props map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && name == "" {
+			structProperties(f.Type, props)
+			continue
+		}
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		schema := typeSchema(f.Type)
+		if name == "state" {
+			schema["enum"] = []string{statusFiring, statusResolved}
+		}
+		props[name] = schema
+	}
+}
+
+// moduleFields groups the JSON names of t's fields by their module tag
+func moduleFields(t reflect.Type) map[string][]string {
+	modules := make(map[string][]string)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		module := f.Tag.Get("module")
+		if module == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		modules[module] = append(modules[module], name)
+	}
+	return modules
+}
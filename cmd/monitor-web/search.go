@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// fulltextIndexName names the optional ENABLE_FULLTEXT index on every alerts table's details
+const fulltextIndexName = "idx_alert_details_fulltext"
+
+// minFulltextQuery is InnoDB's default innodb_ft_min_token_size: shorter terms are not
+// indexed, so they are searched with LIKE instead
+const minFulltextQuery = 3
+
+// likeEscaper escapes LIKE wildcards with '!', which unlike backslash needs no quoting
+// and behaves the same under NO_BACKSLASH_ESCAPES
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// applyDetailsSearch narrows query to alerts whose details contain q, using the FULLTEXT
+// index when ENABLE_FULLTEXT is on and q is long enough to be indexed
+func applyDetailsSearch(query *gorm.DB, q string) *gorm.DB {
+	if viper.GetBool("ENABLE_FULLTEXT") && utf8.RuneCountInString(q) >= minFulltextQuery {
+		return query.Where("MATCH(details) AGAINST (? IN NATURAL LANGUAGE MODE)", q)
+	}
+	return query.Where("details LIKE ? ESCAPE '!'", "%"+likeEscaper.Replace(q)+"%")
+}
+
+// ensureFulltextIndexes creates the FULLTEXT index on details for each model's table if it
+// does not exist yet
+func ensureFulltextIndexes(models ...interface{}) error {
+	for _, model := range models {
+		if db.Migrator().HasIndex(model, fulltextIndexName) {
+			continue
+		}
+		ddl, err := fulltextIndexDDL(model)
+		if err != nil {
+			return err
+		}
+		if err := db.Exec(ddl).Error; err != nil {
+			return fmt.Errorf("failed to create %s: %w", fulltextIndexName, err)
+		}
+	}
+	return nil
+}
+
+// fulltextIndexDDL renders the statement creating the FULLTEXT index on model's table
+func fulltextIndexDDL(model interface{}) (string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return "", fmt.Errorf("failed to parse model: %w", err)
+	}
+	return fmt.Sprintf("CREATE FULLTEXT INDEX %s ON %s (details)",
+		stmt.Quote(fulltextIndexName), stmt.Quote(stmt.Schema.Table)), nil
+}
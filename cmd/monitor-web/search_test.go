@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// searchSQL renders the query applyDetailsSearch builds for q without running it
+func searchSQL(t *testing.T, q string) (string, []interface{}) {
+	t.Helper()
+	dialector, _ := mockDialector(t)
+	dry, err := gorm.Open(dialector, &gorm.Config{DryRun: true, Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+	var rows []map[string]interface{}
+	stmt := applyDetailsSearch(dry.Table("redis_alerts"), q).Find(&rows).Statement
+	return stmt.SQL.String(), stmt.Vars
+}
+
+func TestApplyDetailsSearchEscapesWildcards(t *testing.T) {
+	loadTestConfig(t, nil)
+	tests := map[string]string{
+		"disk 95%":     "%disk 95!%%",
+		"big_keys":     "%big!_keys%",
+		`C:\data`:      `%C:\data%`,
+		"wow!":         "%wow!!%",
+		"50%_off\\now": `%50!%!_off\now%`,
+	}
+	for q, pattern := range tests {
+		sql, vars := searchSQL(t, q)
+		if !strings.Contains(sql, "details LIKE ? ESCAPE '!'") {
+			t.Errorf("q %q: sql = %s, want a LIKE with ESCAPE '!'", q, sql)
+		}
+		if !reflect.DeepEqual(vars, []interface{}{pattern}) {
+			t.Errorf("q %q: args = %v, want [%s]", q, vars, pattern)
+		}
+	}
+}
+
+func TestApplyDetailsSearchFulltext(t *testing.T) {
+	loadTestConfig(t, map[string]string{"ENABLE_FULLTEXT": "true"})
+
+	sql, vars := searchSQL(t, "replication lag")
+	if !strings.Contains(sql, "MATCH(details) AGAINST (? IN NATURAL LANGUAGE MODE)") {
+		t.Errorf("sql = %s, want MATCH ... AGAINST", sql)
+	}
+	if !reflect.DeepEqual(vars, []interface{}{"replication lag"}) {
+		t.Errorf("args = %v, want [replication lag]", vars)
+	}
+
+	// Terms shorter than the full-text token size fall back to LIKE
+	if sql, vars := searchSQL(t, "io"); !strings.Contains(sql, "details LIKE ?") || !reflect.DeepEqual(vars, []interface{}{"%io%"}) {
+		t.Errorf("short term: sql = %s, args = %v; want a LIKE on %%io%%", sql, vars)
+	}
+}
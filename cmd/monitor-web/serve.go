@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// webPort and tlsPort are the WEB_PORT and TLS_PORT the HTTP and HTTPS servers listen on;
+// an empty port disables that server
+var webPort, tlsPort string
+
+// tlsCertFile and tlsKeyFile are the TLS_CERT_FILE and TLS_KEY_FILE the HTTPS server uses
+var tlsCertFile, tlsKeyFile string
+
+// initListeners reads the HTTP and HTTPS ports, checking that at least one is enabled and
+// that the HTTPS server's certificate and key load
+func initListeners() error {
+	webPort = viper.GetString("WEB_PORT")
+	// viper treats an empty variable as unset and would fall back to the 8080 default
+	if v, ok := os.LookupEnv("MONITOR_WEB_WEB_PORT"); ok && v == "" {
+		webPort = ""
+	}
+	tlsPort = viper.GetString("TLS_PORT")
+	tlsCertFile = viper.GetString("TLS_CERT_FILE")
+	tlsKeyFile = viper.GetString("TLS_KEY_FILE")
+	if webPort == "" && tlsPort == "" {
+		return fmt.Errorf("MONITOR_WEB_WEB_PORT and MONITOR_WEB_TLS_PORT are both empty: no listener to serve on")
+	}
+	if webPort != "" && webPort == tlsPort {
+		return fmt.Errorf("invalid MONITOR_WEB_TLS_PORT %q: already used by MONITOR_WEB_WEB_PORT", tlsPort)
+	}
+	if tlsPort != "" {
+		if tlsCertFile == "" || tlsKeyFile == "" {
+			return fmt.Errorf("MONITOR_WEB_TLS_CERT_FILE and MONITOR_WEB_TLS_KEY_FILE are required with MONITOR_WEB_TLS_PORT")
+		}
+		if _, err := tls.LoadX509KeyPair(tlsCertFile, tlsKeyFile); err != nil {
+			return fmt.Errorf("invalid MONITOR_WEB_TLS_CERT_FILE or MONITOR_WEB_TLS_KEY_FILE: %w", err)
+		}
+	}
+	return nil
+}
+
+// shutdownTimeout bounds how long in-flight requests and calls may take to drain on shutdown
+const shutdownTimeout = 15 * time.Second
+
+// serve runs the plain HTTP server on webPort and the HTTPS server on tlsPort, each only when
+// its port is set, and the gRPC server on grpcLis when it is not nil, until SIGINT or SIGTERM,
+// then drains them all before returning. Both HTTP servers share r.
+func serve(r *gin.Engine, grpcLis net.Listener) {
+	var servers []*http.Server
+	if webPort != "" {
+		srv := &http.Server{Addr: ":" + webPort, Handler: r}
+		servers = append(servers, srv)
+		go func() {
+			slog.Info("Starting web server", "port", webPort, "tls", false, "component", "monitor-web")
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slog.Error("Failed to start web server", "error", err, "port", webPort, "component", "monitor-web")
+				os.Exit(1)
+			}
+		}()
+	}
+	if tlsPort != "" {
+		srv := &http.Server{Addr: ":" + tlsPort, Handler: r, TLSConfig: &tls.Config{MinVersion: tls.VersionTLS12}}
+		servers = append(servers, srv)
+		go func() {
+			slog.Info("Starting web server", "port", tlsPort, "tls", true, "component", "monitor-web")
+			if err := srv.ListenAndServeTLS(tlsCertFile, tlsKeyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slog.Error("Failed to start web server", "error", err, "port", tlsPort, "component", "monitor-web")
+				os.Exit(1)
+			}
+		}()
+	}
+	grpcSrv := newGRPCServer()
+	if grpcLis != nil {
+		go func() {
+			slog.Info("Starting gRPC server", "addr", grpcLis.Addr().String(), "component", "monitor-web")
+			if err := grpcSrv.Serve(grpcLis); err != nil {
+				slog.Error("Failed to serve gRPC", "error", err, "component", "monitor-web")
+				os.Exit(1)
+			}
+		}()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	<-ctx.Done()
+	stop()
+	slog.Info("Shutting down", "timeout", shutdownTimeout.String(), "component", "monitor-web")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	grpcDone := make(chan struct{})
+	go func() {
+		grpcSrv.GracefulStop()
+		close(grpcDone)
+	}()
+	var wg sync.WaitGroup
+	for _, srv := range servers {
+		wg.Add(1)
+		go func(srv *http.Server) {
+			defer wg.Done()
+			if err := srv.Shutdown(ctx); err != nil {
+				slog.Error("Failed to drain web server", "error", err, "addr", srv.Addr, "component", "monitor-web")
+			}
+		}(srv)
+	}
+	wg.Wait()
+	select {
+	case <-grpcDone:
+	case <-ctx.Done():
+		slog.Error("Failed to drain gRPC server", "error", ctx.Err(), "component", "monitor-web")
+		grpcSrv.Stop()
+	}
+	slog.Info("Server stopped", "component", "monitor-web")
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/spf13/viper"
+)
+
+// severityRanks orders the SEVERITY_ORDER severities (alert_type values): 0 is the most severe
+var severityRanks map[string]int
+
+// initSeverityOrder parses SEVERITY_ORDER, a comma-separated list of alert_type values from
+// the most to the least severe
+func initSeverityOrder() {
+	severityRanks = make(map[string]int)
+	for _, severity := range splitList(viper.GetString("SEVERITY_ORDER")) {
+		if _, dup := severityRanks[severity]; !dup {
+			severityRanks[severity] = len(severityRanks)
+		}
+	}
+}
+
+// severityRank returns severity's position in SEVERITY_ORDER; unlisted severities rank
+// after every listed one
+func severityRank(severity string) int {
+	if rank, ok := severityRanks[severity]; ok {
+		return rank
+	}
+	return len(severityRanks)
+}
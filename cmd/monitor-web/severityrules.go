@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// severityComparison matches one "field op number" term of a severity rule
+var severityComparison = regexp.MustCompile(`^([a-z_]+)\s*(>=|<=|==|!=|>|<)\s*(-?[0-9]+(?:\.[0-9]+)?)$`)
+
+// severityCondition compares one numeric module field of an event against a constant
+type severityCondition struct {
+	field int // index in AlertModuleData
+	op    string
+	value float64
+}
+
+// severityRule derives severity when any of its AND-groups of conditions all hold
+type severityRule struct {
+	severity string
+	anyOf    [][]severityCondition
+}
+
+// severityRules holds each module's SEVERITY_RULES_<MODULE>, tried in order
+var severityRules map[string][]severityRule
+
+// initSeverityRules parses each module's SEVERITY_RULES_<MODULE>: semicolon-separated
+// "severity: expression" rules, where an expression joins field comparisons with && and ||
+// (&& binding tighter; no parentheses), e.g.
+// MONITOR_WEB_SEVERITY_RULES_HOST="critical: cpu_usage > 90 || disk_usage > 95; warning: cpu_usage > 75".
+// Severities must be ALERT_TYPES and fields numeric fields of the module.
+func initSeverityRules() error {
+	severityRules = make(map[string][]severityRule)
+	for _, module := range storedModules() {
+		key := "SEVERITY_RULES_" + strings.ToUpper(module)
+		value := strings.TrimSpace(viper.GetString(key))
+		if value == "" {
+			continue
+		}
+		fields := numericModuleFields(module)
+		for _, entry := range strings.Split(value, ";") {
+			if strings.TrimSpace(entry) == "" {
+				continue
+			}
+			rule, err := parseSeverityRule(entry, fields)
+			if err != nil {
+				return fmt.Errorf("invalid MONITOR_WEB_%s rule %q: %w", key, strings.TrimSpace(entry), err)
+			}
+			severityRules[module] = append(severityRules[module], rule)
+		}
+	}
+	return nil
+}
+
+// severityRuleCounts returns how many severity rules each module has, for the startup log
+func severityRuleCounts() map[string]int {
+	counts := make(map[string]int, len(severityRules))
+	for module, rules := range severityRules {
+		counts[module] = len(rules)
+	}
+	return counts
+}
+
+// parseSeverityRule parses one "severity: expression" rule over fields, the module's
+// numeric fields by JSON name
+func parseSeverityRule(entry string, fields map[string]int) (severityRule, error) {
+	severity, expr, ok := strings.Cut(entry, ":")
+	if !ok {
+		return severityRule{}, fmt.Errorf("want severity: expression")
+	}
+	canonical, ok := alertTypes[strings.ToLower(strings.TrimSpace(severity))]
+	if !ok {
+		return severityRule{}, fmt.Errorf("severity %q is not in MONITOR_WEB_ALERT_TYPES", strings.TrimSpace(severity))
+	}
+	rule := severityRule{severity: canonical}
+	for _, group := range strings.Split(expr, "||") {
+		var all []severityCondition
+		for _, term := range strings.Split(group, "&&") {
+			m := severityComparison.FindStringSubmatch(strings.TrimSpace(term))
+			if m == nil {
+				return severityRule{}, fmt.Errorf("want field op number, got %q", strings.TrimSpace(term))
+			}
+			field, ok := fields[m[1]]
+			if !ok {
+				return severityRule{}, fmt.Errorf("%s is not a numeric field of the module", m[1])
+			}
+			value, _ := strconv.ParseFloat(m[3], 64) // the pattern only matches numbers
+			all = append(all, severityCondition{field: field, op: m[2], value: value})
+		}
+		rule.anyOf = append(rule.anyOf, all)
+	}
+	return rule, nil
+}
+
+// numericModuleFields maps the JSON names of module's numeric AlertModuleData fields to
+// their field index
+func numericModuleFields(module string) map[string]int {
+	fields := make(map[string]int)
+	t := reflect.TypeOf(AlertModuleData{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Tag.Get("module") != module {
+			continue
+		}
+		switch f.Type.Elem().Kind() {
+		case reflect.Int, reflect.Int64, reflect.Float64:
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			fields[name] = i
+		}
+	}
+	return fields
+}
+
+// deriveSeverity returns the severity of the first of the module's rules event matches, or
+// "" when none does. Fields the event omits satisfy no comparison.
+func deriveSeverity(event *AlertEvent) string {
+	data := reflect.ValueOf(event.AlertModuleData)
+	for _, rule := range severityRules[event.Module] {
+		for _, all := range rule.anyOf {
+			if conditionsHold(data, all) {
+				return rule.severity
+			}
+		}
+	}
+	return ""
+}
+
+// conditionsHold reports whether every condition holds for data, an AlertModuleData
+func conditionsHold(data reflect.Value, conditions []severityCondition) bool {
+	for _, cond := range conditions {
+		field := data.Field(cond.field)
+		if field.IsNil() {
+			return false
+		}
+		var v float64
+		switch elem := field.Elem(); elem.Kind() {
+		case reflect.Float64:
+			v = elem.Float()
+		default:
+			v = float64(elem.Int())
+		}
+		var ok bool
+		switch cond.op {
+		case ">":
+			ok = v > cond.value
+		case ">=":
+			ok = v >= cond.value
+		case "<":
+			ok = v < cond.value
+		case "<=":
+			ok = v <= cond.value
+		case "==":
+			ok = v == cond.value
+		case "!=":
+			ok = v != cond.value
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
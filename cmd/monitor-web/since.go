@@ -0,0 +1,83 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getAlertsSince godoc
+// @Summary Get alerts newer than the last poll
+// @Description Returns alerts with an id greater than id (or, without id, a timestamp after after), oldest first, for incremental polling. lastId is the highest id returned, or the given id when nothing is new; pass it as id on the next poll.
+// @Tags alerts
+// @Produce json
+// @Param module path string true "Module name (e.g., redis, mysql, host, system, general)"
+// @Param id query int false "Last alert id seen"
+// @Param after query string false "RFC 3339 timestamp; used when id is omitted"
+// @Param limit query int false "Maximum alerts to return, clamped to MAX_PAGE_SIZE" default(100)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/{module}/since [get]
+func getAlertsSince(c *gin.Context) {
+	module := moduleParam(c)
+	tableName, ok := moduleTable(module)
+	if !ok {
+		slog.Warn("Invalid module requested", "module", module, "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module"})
+		return
+	}
+
+	limit, err := parseLimit(c, defaultDashboardLimit)
+	if err != nil {
+		slog.Warn("Invalid since limit", "limit", c.Query("limit"), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	query := db.Table(tableName).Limit(limit)
+	var lastID uint64
+	switch idParam, afterParam := c.Query("id"), c.Query("after"); {
+	case idParam != "":
+		if lastID, err = strconv.ParseUint(idParam, 10, 64); err != nil {
+			slog.Warn("Invalid since id", "id", idParam, "component", "monitor-web")
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid id"})
+			return
+		}
+		query = query.Where("id > ?", lastID).Order("id asc")
+	case afterParam != "":
+		after, err := time.Parse(time.RFC3339, afterParam)
+		if err != nil {
+			slog.Warn("Invalid since timestamp", "after", afterParam, "component", "monitor-web")
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid after"})
+			return
+		}
+		query = query.Where("timestamp > ?", after).Order("timestamp asc, id asc")
+	default:
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Missing id or after"})
+		return
+	}
+
+	alerts := []map[string]interface{}{}
+	if err := query.Find(&alerts).Error; err != nil {
+		slog.Error("Failed to query new alerts", "module", module, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+	for _, alert := range alerts {
+		if id, ok := rowID(alert); ok && id > lastID {
+			lastID = id
+		}
+	}
+	decodeJSONColumns(alerts, jsonColumns...)
+	utcTimestamps(alerts)
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"module": module,
+		"alerts": alerts,
+		"lastId": lastID,
+	})
+}
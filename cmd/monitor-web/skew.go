@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxSkewHosts bounds how many offending hosts the skew tracker remembers
+const maxSkewHosts = 1000
+
+var (
+	// clockSkewWarn is the CLOCK_SKEW_WARN beyond which an event's timestamp is logged (0 disables it)
+	clockSkewWarn time.Duration
+	// clockSkewReject is the CLOCK_SKEW_REJECT beyond which an event is rejected (0 disables it)
+	clockSkewReject time.Duration
+)
+
+// hostSkew summarizes the clock skew seen from one host since startup
+type hostSkew struct {
+	Host        string    `json:"host"`
+	Module      string    `json:"module"`
+	LastSkew    float64   `json:"last_skew_seconds"` // event timestamp minus receive time
+	MaxSkew     float64   `json:"max_skew_seconds"`  // largest in magnitude, with its sign
+	Offenses    int64     `json:"offenses"`
+	Rejected    int64     `json:"rejected"`
+	LastOffense time.Time `json:"last_offense"`
+}
+
+var (
+	skewMu sync.Mutex
+	// skewHosts holds only hosts that exceeded a threshold, so it stays small
+	skewHosts = make(map[string]*hostSkew)
+)
+
+// checkClockSkew compares event's timestamp with the receive time, logging a warning past
+// CLOCK_SKEW_WARN and rejecting the event past CLOCK_SKEW_REJECT; both are counted in
+// /stats/skew. Events without a timestamp are not checked.
+func checkClockSkew(event *AlertEvent, clientIP string) error {
+	if event.Timestamp.IsZero() || (clockSkewWarn <= 0 && clockSkewReject <= 0) {
+		return nil
+	}
+	skew := event.Timestamp.Sub(time.Now())
+	magnitude := skew.Abs()
+	reject := clockSkewReject > 0 && magnitude > clockSkewReject
+	if !reject && (clockSkewWarn <= 0 || magnitude <= clockSkewWarn) {
+		return nil
+	}
+
+	host := event.HostIP
+	if host == "" {
+		host = clientIP
+	}
+	recordSkew(host, event.Module, skew, reject)
+	if reject {
+		slog.Warn("Rejected clock-skewed alert", "module", event.Module, "host", host, "skew", skew.Round(time.Millisecond).String(), "max_skew", clockSkewReject.String(), "client_ip", clientIP, "component", "monitor-web")
+		return &ingestError{fmt.Sprintf("Clock skew: timestamp is %s off the server clock, more than %s", magnitude.Round(time.Second), clockSkewReject)}
+	}
+	slog.Warn("Received clock-skewed alert", "module", event.Module, "host", host, "skew", skew.Round(time.Millisecond).String(), "client_ip", clientIP, "component", "monitor-web")
+	return nil
+}
+
+// recordSkew adds one offense by host to the tracker
+func recordSkew(host, module string, skew time.Duration, rejected bool) {
+	skewMu.Lock()
+	defer skewMu.Unlock()
+	h, ok := skewHosts[host]
+	if !ok {
+		if len(skewHosts) >= maxSkewHosts {
+			return
+		}
+		h = &hostSkew{Host: host}
+		skewHosts[host] = h
+	}
+	h.Module = module
+	h.LastSkew = skew.Seconds()
+	if math.Abs(skew.Seconds()) > math.Abs(h.MaxSkew) {
+		h.MaxSkew = skew.Seconds()
+	}
+	h.Offenses++
+	if rejected {
+		h.Rejected++
+	}
+	h.LastOffense = time.Now().UTC()
+}
+
+// getSkewStats godoc
+// @Summary List the hosts with the most skewed clocks
+// @Description Summarizes, since startup, the hosts whose alert timestamps were further from the server clock than CLOCK_SKEW_WARN (or CLOCK_SKEW_REJECT): their last and largest skew in seconds (positive when the host runs ahead), how often they offended and how many alerts were rejected, worst first. At most 1000 hosts are tracked.
+// @Tags stats
+// @Produce json
+// @Param limit query int false "Hosts to list, at most MAX_PAGE_SIZE" default(20)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Router /stats/skew [get]
+func getSkewStats(c *gin.Context) {
+	limit, err := parseLimit(c, 20)
+	if err != nil {
+		slog.Warn("Invalid skew stats limit", "limit", c.Query("limit"), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	skewMu.Lock()
+	hosts := make([]hostSkew, 0, len(skewHosts))
+	for _, h := range skewHosts {
+		hosts = append(hosts, *h)
+	}
+	skewMu.Unlock()
+	sort.Slice(hosts, func(i, j int) bool {
+		if si, sj := math.Abs(hosts[i].MaxSkew), math.Abs(hosts[j].MaxSkew); si != sj {
+			return si > sj
+		}
+		return hosts[i].Host < hosts[j].Host
+	})
+	if len(hosts) > limit {
+		hosts = hosts[:limit]
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"warnThreshold":   clockSkewWarn.String(),
+		"rejectThreshold": clockSkewReject.String(),
+		"hosts":           hosts,
+	})
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	spoolFileName      = "alerts.spool"
+	replayingFileName  = "alerts.spool.replaying"
+	deadLetterFileName = "alerts.spool.dead"    // spooled alerts the database will never accept
+	insertRetryBackoff = 200 * time.Millisecond // grows linearly with each retry
+)
+
+var (
+	// spoolDir is the SPOOL_DIR holding alerts whose insert failed ("" disables spooling)
+	spoolDir string
+	// spoolReplayInterval is how often spooled alerts are retried
+	spoolReplayInterval time.Duration
+	// spoolMu serializes appends to the spool with the replayer moving it aside
+	spoolMu sync.Mutex
+)
+
+// spoolEntry is one spooled alert: the event as prepareRecord left it, plus the mute
+// decision and stored attachment from ingest so replay stores exactly what the original
+// insert would have
+type spoolEntry struct {
+	Event          AlertEvent `json:"event"`
+	Suppressed     bool       `json:"suppressed"`
+	AttachmentFile string     `json:"attachment_file,omitempty"`
+	AttachmentType string     `json:"attachment_type,omitempty"`
+	SpooledAt      time.Time  `json:"spooled_at"`
+}
+
+// initSpool reads SPOOL_DIR and SPOOL_REPLAY_INTERVAL, creating the spool directory and
+// checking that the spool file is writable
+func initSpool() error {
+	spoolDir = viper.GetString("SPOOL_DIR")
+	if spoolDir == "" {
+		return nil
+	}
+	var err error
+	if spoolReplayInterval, err = parseDuration(viper.GetString("SPOOL_REPLAY_INTERVAL")); err != nil || spoolReplayInterval <= 0 {
+		return fmt.Errorf("invalid MONITOR_WEB_SPOOL_REPLAY_INTERVAL %q: want a positive duration", viper.GetString("SPOOL_REPLAY_INTERVAL"))
+	}
+	if err := os.MkdirAll(spoolDir, 0o700); err != nil {
+		return fmt.Errorf("invalid MONITOR_WEB_SPOOL_DIR: %w", err)
+	}
+	if err := appendSpoolLines(filepath.Join(spoolDir, spoolFileName), nil); err != nil {
+		return fmt.Errorf("invalid MONITOR_WEB_SPOOL_DIR: %w", err)
+	}
+	return nil
+}
+
+// createWithRetry inserts record, retrying INSERT_RETRIES times with a growing backoff.
+// Errors other than transient ones are returned at once since retrying cannot fix them.
+func createWithRetry(record alertRecord) error {
+	retries := viper.GetInt("INSERT_RETRIES")
+	for attempt := 0; ; attempt++ {
+		err := db.Create(record).Error
+		if err == nil {
+			invalidateFilterCache(record.base().Module)
+			return nil
+		}
+		if !isTransientDBError(err) || attempt >= retries {
+			return err
+		}
+		time.Sleep(time.Duration(attempt+1) * insertRetryBackoff)
+	}
+}
+
+// spoolAlert appends the prepared event of record to the spool for the replayer to insert
+// later; ingest then answers the client 202 with status spooled
+func spoolAlert(event *AlertEvent, record alertRecord) error {
+	alert := record.base()
+	line, err := json.Marshal(spoolEntry{
+		Event:          *event,
+		Suppressed:     alert.Suppressed,
+		AttachmentFile: alert.AttachmentFile,
+		AttachmentType: alert.AttachmentType,
+		SpooledAt:      time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	spoolMu.Lock()
+	defer spoolMu.Unlock()
+	return appendSpoolLines(filepath.Join(spoolDir, spoolFileName), [][]byte{line})
+}
+
+// appendSpoolLines appends lines to the file at path and syncs it, so a spooled alert
+// survives a crash once the client has been answered
+func appendSpoolLines(path string, lines [][]byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, line := range lines {
+		_, _ = w.Write(line)
+		_ = w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// replaySpoolPeriodically retries the spooled alerts every SPOOL_REPLAY_INTERVAL
+func replaySpoolPeriodically() {
+	if spoolDir == "" {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(spoolReplayInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			replaySpool()
+		}
+	}()
+}
+
+// replaySpool moves the spool aside and inserts its alerts in order. At the first insert
+// that fails transiently it stops and keeps the rest for the next run, ahead of anything
+// spooled since; an alert the database rejects outright is moved to the dead-letter file
+// so it cannot block the alerts behind it. Alerts may be inserted twice if the process
+// dies mid-replay, so delivery is at-least-once.
+func replaySpool() {
+	spoolPath := filepath.Join(spoolDir, spoolFileName)
+	replayingPath := filepath.Join(spoolDir, replayingFileName)
+
+	// A replay that stopped early, or was cut short by a restart, resumes before newer alerts
+	if _, err := os.Stat(replayingPath); errors.Is(err, fs.ErrNotExist) {
+		spoolMu.Lock()
+		info, err := os.Stat(spoolPath)
+		if err == nil && info.Size() > 0 {
+			err = os.Rename(spoolPath, replayingPath)
+		} else if err == nil {
+			err = fs.ErrNotExist // nothing spooled
+		}
+		spoolMu.Unlock()
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				slog.Error("Failed to move spool aside for replay", "error", err, "component", "monitor-web")
+			}
+			return
+		}
+	}
+
+	f, err := os.Open(replayingPath)
+	if err != nil {
+		slog.Error("Failed to open spool for replay", "error", err, "component", "monitor-web")
+		return
+	}
+	defer f.Close()
+
+	deadPath := filepath.Join(spoolDir, deadLetterFileName)
+	var stored, dropped, dead int
+	var remaining [][]byte
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxImportLineSize)
+	for scanner.Scan() {
+		line := append([]byte(nil), scanner.Bytes()...)
+		if remaining != nil {
+			remaining = append(remaining, line)
+			continue
+		}
+		var entry spoolEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			slog.Error("Dropped unreadable spooled alert", "error", err, "component", "monitor-web")
+			dropped++
+			continue
+		}
+		record := buildRecord(&entry.Event, entry.Suppressed)
+		record.base().AttachmentFile = entry.AttachmentFile
+		record.base().AttachmentType = entry.AttachmentType
+		err := db.Create(record).Error
+		switch {
+		case err == nil:
+			invalidateFilterCache(record.base().Module)
+			stored++
+		case isDuplicateKeyError(err):
+			dropped++ // already stored by an earlier, interrupted replay
+		case isTransientDBError(err):
+			slog.Warn("Spool replay paused; database still failing", "error", err, "component", "monitor-web")
+			remaining = [][]byte{line}
+		default:
+			if deadErr := appendSpoolLines(deadPath, [][]byte{line}); deadErr != nil {
+				slog.Error("Failed to dead-letter spooled alert; replay paused", "error", deadErr, "component", "monitor-web")
+				remaining = [][]byte{line}
+				continue
+			}
+			slog.Error("Moved rejected spooled alert to dead-letter file", "module", entry.Event.Module, "event_name", entry.Event.EventName, "error", err, "path", deadPath, "component", "monitor-web")
+			dead++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		slog.Error("Failed to read spool for replay", "error", err, "component", "monitor-web")
+		return
+	}
+
+	if remaining == nil {
+		err = os.Remove(replayingPath)
+	} else {
+		// Rewrite via a temporary file so a crash never loses the unreplayed alerts
+		tmp := replayingPath + ".tmp"
+		if err = os.Remove(tmp); errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+		if err == nil {
+			err = appendSpoolLines(tmp, remaining)
+		}
+		if err == nil {
+			err = os.Rename(tmp, replayingPath)
+		}
+	}
+	if err != nil {
+		slog.Error("Failed to update spool after replay", "error", err, "component", "monitor-web")
+	}
+	if stored > 0 || dropped > 0 || dead > 0 {
+		slog.Info("Replayed spooled alerts", "stored", stored, "dropped", dropped, "dead_lettered", dead, "remaining", len(remaining), "component", "monitor-web")
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mysqldriver "github.com/go-sql-driver/mysql"
+)
+
+// writeSpool spools one redis alert per event name into a fresh SPOOL_DIR
+func writeSpool(t *testing.T, eventNames ...string) {
+	t.Helper()
+	prev := spoolDir
+	spoolDir = t.TempDir()
+	t.Cleanup(func() { spoolDir = prev })
+	lines := make([][]byte, 0, len(eventNames))
+	for _, name := range eventNames {
+		event := testEvent(time.Now())
+		event.EventName = name
+		line, err := json.Marshal(spoolEntry{Event: *event, SpooledAt: time.Now().UTC()})
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		lines = append(lines, line)
+	}
+	if err := appendSpoolLines(filepath.Join(spoolDir, spoolFileName), lines); err != nil {
+		t.Fatalf("appendSpoolLines: %v", err)
+	}
+}
+
+// spooledEventNames returns the event names left in the spool file called name
+func spooledEventNames(t *testing.T, name string) []string {
+	t.Helper()
+	f, err := os.Open(filepath.Join(spoolDir, name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("open %s: %v", name, err)
+	}
+	defer f.Close()
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry spoolEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("%s holds an unreadable line: %v", name, err)
+		}
+		names = append(names, entry.Event.EventName)
+	}
+	return names
+}
+
+func TestReplaySpool(t *testing.T) {
+	loadTestConfig(t, nil)
+	mock := mockDB(t)
+	writeSpool(t, "stored", "too_long", "duplicate", "offline", "behind_offline")
+
+	insert := "INSERT INTO `redis_alerts`"
+	mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(1, 1))
+	// A value the database will never accept is dead-lettered instead of blocking the rest
+	mock.ExpectExec(insert).WillReturnError(&mysqldriver.MySQLError{Number: 1406, Message: "Data too long for column 'event_name'"})
+	mock.ExpectExec(insert).WillReturnError(&mysqldriver.MySQLError{Number: mysqlDuplicateEntry, Message: "Duplicate entry"})
+	// A connection failure pauses the replay, keeping the alert and everything after it
+	mock.ExpectExec(insert).WillReturnError(mysqldriver.ErrInvalidConn)
+	replaySpool()
+
+	if got := spooledEventNames(t, deadLetterFileName); len(got) != 1 || got[0] != "too_long" {
+		t.Errorf("dead-lettered %v, want [too_long]", got)
+	}
+	if got := spooledEventNames(t, replayingFileName); len(got) != 2 || got[0] != "offline" || got[1] != "behind_offline" {
+		t.Errorf("kept %v for the next run, want [offline behind_offline]", got)
+	}
+
+	// The next run resumes with the kept alerts once the database is back
+	mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(3, 1))
+	replaySpool()
+	if _, err := os.Stat(filepath.Join(spoolDir, replayingFileName)); !os.IsNotExist(err) {
+		t.Errorf("replaying file still present after a full replay: %v", err)
+	}
+}
+
+func TestIngestSpoolsOnlyTransientFailures(t *testing.T) {
+	loadTestConfig(t, map[string]string{"INSERT_RETRIES": "0"})
+	mock := mockDB(t)
+	writeSpool(t)
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		spooled bool
+	}{
+		{"data too long", &mysqldriver.MySQLError{Number: 1406, Message: "Data too long for column 'service_name'"}, http.StatusBadRequest, false},
+		{"connection lost", mysqldriver.ErrInvalidConn, http.StatusAccepted, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectQuery("FROM `host_mutes`").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			mock.ExpectExec("INSERT INTO `redis_alerts`").WillReturnError(tt.err)
+			event := testEvent(time.Now())
+			event.EventName = tt.name
+			if status, resp := ingestEvent(event, "192.0.2.1", ""); status != tt.status {
+				t.Errorf("ingestEvent = %d %v, want %d", status, resp, tt.status)
+			}
+			spooled := false
+			for _, name := range spooledEventNames(t, spoolFileName) {
+				spooled = spooled || name == tt.name
+			}
+			if spooled != tt.spooled {
+				t.Errorf("spooled = %v, want %v", spooled, tt.spooled)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tableStorage is the size of one module's alerts table
+type tableStorage struct {
+	Module string `gorm:"-" json:"module"`
+	Table  string `gorm:"column:table_name" json:"table"`
+	Rows   int64  `gorm:"column:table_rows" json:"rows"`
+	Bytes  int64  `gorm:"column:total_bytes" json:"bytes"`
+}
+
+// getStorageStats godoc
+// @Summary Get per-module storage usage
+// @Description Returns row counts and on-disk sizes (data plus indexes) for each module's alerts table. MySQL row counts are InnoDB estimates.
+// @Tags stats
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /stats/storage [get]
+func getStorageStats(c *gin.Context) {
+	modules := storedModules()
+	tables := make([]string, 0, len(modules))
+	moduleOf := make(map[string]string, len(modules))
+	for _, module := range modules {
+		table, _ := moduleTable(module)
+		tables = append(tables, table)
+		moduleOf[table] = module
+	}
+
+	var sizes []tableStorage
+	var err error
+	switch db.Dialector.Name() {
+	case "postgres":
+		err = db.Raw(`SELECT relname AS table_name, n_live_tup AS table_rows, pg_total_relation_size(relid) AS total_bytes
+			FROM pg_stat_user_tables WHERE relname IN ?`, tables).Scan(&sizes).Error
+	default:
+		err = db.Raw(`SELECT table_name AS table_name, table_rows AS table_rows, data_length + index_length AS total_bytes
+			FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name IN ?`, tables).Scan(&sizes).Error
+	}
+	if err != nil {
+		slog.Error("Failed to query table sizes", "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query storage stats"})
+		return
+	}
+
+	var totalRows, totalBytes int64
+	for i := range sizes {
+		sizes[i].Module = moduleOf[sizes[i].Table]
+		totalRows += sizes[i].Rows
+		totalBytes += sizes[i].Bytes
+	}
+	respondJSON(c, http.StatusOK, gin.H{
+		"tables":     sizes,
+		"totalRows":  totalRows,
+		"totalBytes": totalBytes,
+	})
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// tableOptionValue restricts table option values to bare identifiers, since they are
+// spliced into CREATE TABLE unquoted
+var tableOptionValue = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// dbSchemaName restricts DB_SCHEMA to lowercase identifiers, as it becomes part of every table name
+var dbSchemaName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// tablePrefix namespaces every table by DB_SCHEMA ("staging_" for staging), so several
+// environments can share one database without their alerts colliding
+var tablePrefix string
+
+var (
+	// defaultTableOptions are the MySQL table options built from the DB_TABLE_* settings
+	defaultTableOptions string
+	// moduleTableOptions are per-module overrides from DB_TABLE_OPTIONS_<MODULE>
+	moduleTableOptions map[string]string
+)
+
+// initTablePrefix derives tablePrefix from DB_SCHEMA; unset keeps the unprefixed table names
+func initTablePrefix() error {
+	tablePrefix = ""
+	name := viper.GetString("DB_SCHEMA")
+	if name == "" {
+		return nil
+	}
+	if !dbSchemaName.MatchString(name) {
+		return fmt.Errorf("invalid MONITOR_WEB_DB_SCHEMA %q: want lowercase letters, digits and underscores", name)
+	}
+	tablePrefix = name + "_"
+	return nil
+}
+
+// initTableOptions builds the table options applied when migrations create tables:
+// DB_TABLE_ENGINE, DB_TABLE_CHARSET, DB_TABLE_COLLATION and DB_TABLE_ROW_FORMAT for every
+// table, replaced wholesale for a module's table by DB_TABLE_OPTIONS_<MODULE>
+func initTableOptions() error {
+	var opts []string
+	for _, opt := range []struct{ key, clause string }{
+		{"DB_TABLE_ENGINE", "ENGINE"},
+		{"DB_TABLE_CHARSET", "DEFAULT CHARSET"},
+		{"DB_TABLE_COLLATION", "COLLATE"},
+		{"DB_TABLE_ROW_FORMAT", "ROW_FORMAT"},
+	} {
+		value := viper.GetString(opt.key)
+		if value == "" {
+			continue
+		}
+		if !tableOptionValue.MatchString(value) {
+			return fmt.Errorf("invalid MONITOR_WEB_%s %q", opt.key, value)
+		}
+		opts = append(opts, opt.clause+"="+value)
+	}
+	defaultTableOptions = strings.Join(opts, " ")
+
+	moduleTableOptions = make(map[string]string)
+	for _, module := range storedModules() {
+		key := "DB_TABLE_OPTIONS_" + strings.ToUpper(module)
+		value := strings.TrimSpace(viper.GetString(key))
+		if value == "" {
+			continue
+		}
+		for _, opt := range strings.Fields(value) {
+			name, v, ok := strings.Cut(opt, "=")
+			if !ok || !tableOptionValue.MatchString(name) || !tableOptionValue.MatchString(v) {
+				return fmt.Errorf("invalid MONITOR_WEB_%s option %q: want NAME=value", key, opt)
+			}
+		}
+		moduleTableOptions[module] = value
+	}
+	return nil
+}
+
+// tableOptionsFor returns the table options for model's table
+func tableOptionsFor(model interface{}) string {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	module := ""
+	if t == reflect.TypeOf(Alert{}) {
+		module = "general"
+	}
+	for name, m := range moduleModels {
+		if reflect.TypeOf(m) == t {
+			module = name
+		}
+	}
+	if opts, ok := moduleTableOptions[module]; ok {
+		return opts
+	}
+	return defaultTableOptions
+}
+
+// withTableOptions returns tx set to create model's table with its table options
+func withTableOptions(tx *gorm.DB, model interface{}) *gorm.DB {
+	return tx.Set("gorm:table_options", tableOptionsFor(model))
+}
+
+// migrateModels auto-migrates each model with its own table options
+func migrateModels(models ...interface{}) error {
+	for _, model := range models {
+		if err := withTableOptions(db, model).AutoMigrate(model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
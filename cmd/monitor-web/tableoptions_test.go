@@ -0,0 +1,56 @@
+package main
+
+import (
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+)
+
+// modelTable is the table gorm names model under the current tablePrefix
+func modelTable(t *testing.T, model interface{}) string {
+	t.Helper()
+	s, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{TablePrefix: tablePrefix})
+	if err != nil {
+		t.Fatalf("parse %T: %v", model, err)
+	}
+	return s.Table
+}
+
+func TestTablePrefixSeparatesEnvironments(t *testing.T) {
+	tables := map[string]string{}
+	for _, env := range []string{"staging", "dev"} {
+		loadTestConfig(t, map[string]string{"DB_SCHEMA": env})
+		for module, model := range map[string]interface{}{"redis": &RedisAlert{}, "general": &Alert{}} {
+			table, ok := moduleTable(module)
+			if !ok {
+				t.Fatalf("moduleTable(%q) not found", module)
+			}
+			if migrated := modelTable(t, model); migrated != table {
+				t.Errorf("DB_SCHEMA=%s %s: moduleTable = %s, gorm migrates %s", env, module, table, migrated)
+			}
+			tables[env+"/"+module] = table
+		}
+	}
+	want := map[string]string{
+		"staging/redis":   "staging_redis_alerts",
+		"staging/general": "staging_alerts",
+		"dev/redis":       "dev_redis_alerts",
+		"dev/general":     "dev_alerts",
+	}
+	for key, table := range want {
+		if tables[key] != table {
+			t.Errorf("%s table = %q, want %q", key, tables[key], table)
+		}
+	}
+}
+
+func TestInitTablePrefixRejectsInvalidSchema(t *testing.T) {
+	loadTestConfig(t, nil)
+	for _, name := range []string{"Staging", "stag-ing", "dev;drop"} {
+		t.Setenv("MONITOR_WEB_DB_SCHEMA", name)
+		if err := initTablePrefix(); err == nil {
+			t.Errorf("initTablePrefix accepted DB_SCHEMA=%q", name)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// getTail godoc
+// @Summary Tail the most recent alerts across modules
+// @Description A polling-friendly combined live view: returns the n most recent alerts merged across the requested modules (every module when modules is omitted), newest first. Entries carry the shared alert columns and their source module, like /feed. n is clamped to MAX_PAGE_SIZE.
+// @Tags alerts
+// @Produce json
+// @Param n query int false "Number of alerts, clamped to MAX_PAGE_SIZE" default(50)
+// @Param modules query string false "Comma-separated module names (e.g., redis,host); all modules when omitted"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tail [get]
+func getTail(c *gin.Context) {
+	n, err := strconv.Atoi(c.DefaultQuery("n", strconv.Itoa(defaultPageSize)))
+	if err != nil || n < 1 {
+		slog.Warn("Invalid tail size", "n", c.Query("n"), "component", "monitor-web")
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid n"})
+		return
+	}
+	if max := viper.GetInt("MAX_PAGE_SIZE"); n > max {
+		n = max
+	}
+
+	modules := storedModules()
+	if param := c.Query("modules"); param != "" {
+		modules = nil
+		seen := make(map[string]bool)
+		for _, name := range splitList(param) {
+			module := canonicalModule(name)
+			if _, ok := moduleTable(module); !ok {
+				slog.Warn("Invalid module requested", "module", name, "component", "monitor-web")
+				respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid module: " + name})
+				return
+			}
+			if !seen[module] {
+				seen[module] = true
+				modules = append(modules, module)
+			}
+		}
+		if len(modules) == 0 {
+			respondJSON(c, http.StatusBadRequest, gin.H{"error": "Invalid modules"})
+			return
+		}
+	}
+
+	// Each branch contributes at most n rows, enough for the merged top n
+	columns := strings.Join(sharedColumnNames(), ", ")
+	parts := make([]string, 0, len(modules))
+	args := make([]interface{}, 0, len(modules))
+	for _, module := range modules {
+		table, _ := moduleTable(module)
+		parts = append(parts, "(?)")
+		args = append(args, db.Table(table).
+			Select(columns+", ? AS source", module).
+			Order("timestamp desc, id desc").
+			Limit(n))
+	}
+
+	entries := []map[string]interface{}{}
+	sql := "SELECT * FROM (" + strings.Join(parts, " UNION ALL ") + ") AS tail ORDER BY timestamp DESC, source, id DESC LIMIT ?"
+	if err := db.Raw(sql, append(args, n)...).Scan(&entries).Error; err != nil {
+		slog.Error("Failed to query alert tail", "modules", modules, "error", err, "component", "monitor-web")
+		respondJSON(c, http.StatusInternalServerError, gin.H{"error": "Failed to query alerts"})
+		return
+	}
+	decodeJSONColumns(entries, jsonColumns...)
+	utcTimestamps(entries)
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"modules": modules,
+		"n":       n,
+		"entries": entries,
+	})
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireAdminRejectsInEnvelope(t *testing.T) {
+	loadTestConfig(t, map[string]string{"ADMIN_KEY": "s3cr3t", "RESPONSE_ENVELOPE": "true"})
+	r := gin.New()
+	r.POST("/api/test-alert", requireAdmin, func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	req := httptest.NewRequest(http.MethodPost, "/api/test-alert", nil)
+	req.Header.Set(adminKeyHeader, "guess")
+	w := serveRequest(r, req)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	var resp struct {
+		APIVersion int                    `json:"api_version"`
+		Error      map[string]interface{} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("response is not JSON: %v: %s", err, w.Body.String())
+	}
+	if resp.APIVersion != apiVersion || resp.Error["message"] != "Admin key required" {
+		t.Errorf("response = %s, want the envelope", w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/test-alert", nil)
+	req.Header.Set(adminKeyHeader, "s3cr3t")
+	if w := serveRequest(r, req); w.Code != http.StatusNoContent {
+		t.Errorf("status with the admin key = %d, want %d", w.Code, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/viper"
+)
+
+// truncationMarker is appended to text fields cut down to MAX_DETAILS_BYTES
+const truncationMarker = "…[truncated]"
+
+// truncateEventFields caps details and the other free-text fields of event at
+// MAX_DETAILS_BYTES and returns the JSON names of the fields it truncated
+func truncateEventFields(event *AlertEvent) []string {
+	limit := viper.GetInt("MAX_DETAILS_BYTES")
+	if limit <= 0 {
+		return nil
+	}
+	var truncated []string
+	if s, ok := truncateText(event.Details, limit); ok {
+		event.Details = s
+		truncated = append(truncated, "details")
+	}
+	for name, field := range listFields(event) {
+		if field == nil {
+			continue
+		}
+		if s, ok := truncateText(*field, limit); ok {
+			*field = s
+			truncated = append(truncated, name)
+		}
+	}
+	sort.Strings(truncated)
+	return truncated
+}
+
+// listFields are event's module-specific list fields by JSON name; each holds a
+// comma- or newline-separated list of entries
+func listFields(event *AlertEvent) map[string]*string {
+	return map[string]*string{
+		"failed_nodes":      event.FailedNodes,
+		"added_users":       event.AddedUsers,
+		"removed_users":     event.RemovedUsers,
+		"added_processes":   event.AddedProcesses,
+		"removed_processes": event.RemovedProcesses,
+	}
+}
+
+// capListEntries keeps at most MAX_LIST_ENTRIES entries of each list field of event,
+// noting how many were dropped, and returns the dropped counts by field JSON name
+func capListEntries(event *AlertEvent) map[string]int {
+	limit := viper.GetInt("MAX_LIST_ENTRIES")
+	if limit <= 0 {
+		return nil
+	}
+	dropped := make(map[string]int)
+	for name, field := range listFields(event) {
+		if field == nil {
+			continue
+		}
+		if s, n := capList(*field, limit); n > 0 {
+			*field = s
+			dropped[name] = n
+		}
+	}
+	return dropped
+}
+
+// capList keeps the first limit entries of list, separated by newlines when it has any and
+// by commas otherwise, and appends a note of how many entries it dropped
+func capList(list string, limit int) (string, int) {
+	sep := ","
+	if strings.Contains(list, "\n") {
+		sep = "\n"
+	}
+	entries := strings.Split(strings.TrimRight(list, sep), sep)
+	if len(entries) <= limit {
+		return list, 0
+	}
+	dropped := len(entries) - limit
+	return strings.Join(entries[:limit], sep) + sep + fmt.Sprintf("…[%d more]", dropped), dropped
+}
+
+// truncateText shortens s to at most limit bytes, marker included, without splitting a UTF-8
+// sequence. A limit too small to hold the marker cuts s bare.
+func truncateText(s string, limit int) (string, bool) {
+	if len(s) <= limit {
+		return s, false
+	}
+	marker := truncationMarker
+	cut := limit - len(marker)
+	if cut < 0 {
+		marker, cut = "", max(limit, 0)
+	}
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + marker, true
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateEventFieldsKeepsRunesWhole(t *testing.T) {
+	const limit = 64
+	loadTestConfig(t, map[string]string{"MAX_DETAILS_BYTES": "64"})
+
+	// The cut point, limit minus the marker, falls inside the three-byte euro sign
+	cut := limit - len(truncationMarker)
+	details := strings.Repeat("a", cut-1) + "€" + strings.Repeat("b", limit)
+	users := strings.Repeat("ü", limit)
+	var event AlertEvent
+	event.Details = details
+	event.AddedUsers = &users
+
+	truncated := truncateEventFields(&event)
+	if strings.Join(truncated, ",") != "added_users,details" {
+		t.Errorf("truncated = %v, want [added_users details]", truncated)
+	}
+	for name, got := range map[string]string{"details": event.Details, "added_users": *event.AddedUsers} {
+		if !utf8.ValidString(got) {
+			t.Errorf("%s is not valid UTF-8: %q", name, got)
+		}
+		if len(got) > limit {
+			t.Errorf("%s is %d bytes, want at most %d", name, len(got), limit)
+		}
+		if !strings.HasSuffix(got, truncationMarker) {
+			t.Errorf("%s = %q, want the truncation marker", name, got)
+		}
+	}
+	if want := strings.Repeat("a", cut-1) + truncationMarker; event.Details != want {
+		t.Errorf("details = %q, want %q", event.Details, want)
+	}
+}
+
+func TestTruncateEventFieldsLeavesShortValues(t *testing.T) {
+	loadTestConfig(t, map[string]string{"MAX_DETAILS_BYTES": "64"})
+	var event AlertEvent
+	event.Details = "disk almost full"
+	if truncated := truncateEventFields(&event); len(truncated) != 0 || event.Details != "disk almost full" {
+		t.Errorf("truncated %v, details = %q", truncated, event.Details)
+	}
+}
+
+func TestTruncateTextBelowMarkerLength(t *testing.T) {
+	limit := len(truncationMarker) - 1
+	got, truncated := truncateText(strings.Repeat("a", limit-1)+"€€", limit)
+	if !truncated || len(got) > limit || !utf8.ValidString(got) {
+		t.Errorf("truncateText = %q, %v; want at most %d bytes of valid UTF-8", got, truncated, limit)
+	}
+	if want := strings.Repeat("a", limit-1); got != want {
+		t.Errorf("truncateText = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// uiModule is one queryable module and the columns its listing returns
+type uiModule struct {
+	Module  string      `json:"module"`
+	Columns []columnDef `json:"columns"`
+}
+
+// uiFilter is one query parameter the read endpoints filter by
+type uiFilter struct {
+	Param  string   `json:"param"`
+	Type   string   `json:"type"`
+	Values []string `json:"values,omitempty"`
+}
+
+// uiSeverity is one canonical alert_type with its SEVERITY_ORDER rank and chart color
+type uiSeverity struct {
+	Value string `json:"value"`
+	Rank  int    `json:"rank"`
+	Color string `json:"color,omitempty"`
+}
+
+// getUIConfig godoc
+// @Summary Get configuration hints for rendering the UI
+// @Description Returns what a front-end needs to render itself, derived from the live configuration and models: the queryable modules with their columns, the filters applyAlertFilters understands, the canonical severities (ALERT_TYPES) ranked by SEVERITY_ORDER with their SEVERITY_COLORS, the chart buckets, DEFAULT_RANGE, the page-size limits and API_BASE_PATH.
+// @Tags meta
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /ui-config [get]
+func getUIConfig(c *gin.Context) {
+	modules := make([]uiModule, 0, len(validModules))
+	for _, module := range validModules {
+		modules = append(modules, uiModule{Module: module, Columns: moduleColumns(module)})
+	}
+
+	severities := []uiSeverity{}
+	values := []string{}
+	for _, value := range splitList(viper.GetString("ALERT_TYPES")) {
+		severities = append(severities, uiSeverity{Value: value, Rank: severityRank(value), Color: severityColors[value]})
+	}
+	sort.SliceStable(severities, func(i, j int) bool { return severities[i].Rank < severities[j].Rank })
+	for _, s := range severities {
+		values = append(values, s.Value)
+	}
+
+	buckets := make([]string, 0, len(chartBuckets))
+	for bucket := range chartBuckets {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+
+	var rangeHint gin.H
+	if defaultRange > 0 {
+		rangeHint = gin.H{"duration": viper.GetString("DEFAULT_RANGE"), "seconds": int64(defaultRange.Seconds())}
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{
+		"basePath": apiBasePath,
+		"modules":  modules,
+		"filters": []uiFilter{
+			{Param: "from", Type: "date"},
+			{Param: "to", Type: "date"},
+			{Param: "alert_type", Type: "enum", Values: values},
+			{Param: "host_ip", Type: "string"},
+			{Param: "owner_team", Type: "string"},
+			{Param: "environment", Type: "string"},
+			{Param: "role", Type: "string"},
+			{Param: "host_owner", Type: "string"},
+			{Param: "q", Type: "text"},
+			{Param: "label.{key}", Type: "label"},
+		},
+		"severities":   severities,
+		"chartBuckets": buckets,
+		"defaultRange": rangeHint,
+		"pagination": gin.H{
+			"defaultLimit":    viper.GetInt("DASHBOARD_LIMIT"),
+			"defaultPageSize": defaultPageSize,
+			"maxPageSize":     viper.GetInt("MAX_PAGE_SIZE"),
+		},
+	})
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// uniqueIndexName names the optional ENFORCE_UNIQUE index on every alerts table
+const uniqueIndexName = "idx_alert_unique_event"
+
+// ensureUniqueIndexes creates the unique (module, event_name, host_ip, timestamp) index on each
+// model's table if it does not exist yet. Creation fails if the table already holds duplicates.
+func ensureUniqueIndexes(models ...interface{}) error {
+	for _, model := range models {
+		if db.Migrator().HasIndex(model, uniqueIndexName) {
+			continue
+		}
+		ddl, err := uniqueIndexDDL(model)
+		if err != nil {
+			return err
+		}
+		if err := db.Exec(ddl).Error; err != nil {
+			return fmt.Errorf("failed to create %s: %w", uniqueIndexName, err)
+		}
+	}
+	return nil
+}
+
+// uniqueIndexDDL renders the statement creating the unique index on model's table
+func uniqueIndexDDL(model interface{}) (string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return "", fmt.Errorf("failed to parse model: %w", err)
+	}
+	return fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (module, event_name, host_ip, timestamp)",
+		stmt.Quote(uniqueIndexName), stmt.Quote(stmt.Schema.Table)), nil
+}
+
+// countDuplicate records one more ignored repeat of the stored alert id
+func countDuplicate(record alertRecord, id uint64) error {
+	return db.Model(record).Where("id = ?", id).UpdateColumn("duplicates", gorm.Expr("duplicates + 1")).Error
+}
+
+// existingAlertID looks up the stored row that record collided with under the unique index
+func existingAlertID(record alertRecord) (uint64, error) {
+	a := record.base()
+	var id uint64
+	// Read from the primary: the colliding row may not have reached the replica yet
+	err := db.Clauses(dbresolver.Write).Model(record).
+		Select("id").
+		Where("module = ? AND event_name = ? AND host_ip = ? AND timestamp = ?", a.Module, a.EventName, a.HostIP, a.Timestamp).
+		Limit(1).
+		Scan(&id).Error
+	if err == nil && id == 0 {
+		err = gorm.ErrRecordNotFound
+	}
+	return id, err
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// schemaVersionHeader lets a client send v2 payloads to the v1 ingest endpoint
+const schemaVersionHeader = "X-Schema-Version"
+
+// AlertEventV2 is the nested alert payload sent by monitor-service v2
+type AlertEventV2 struct {
+	Common     AlertCommon     `json:"common"`
+	ModuleData AlertModuleData `json:"module_data"`
+}
+
+// flatten converts the nested payload into the flat event the storage path works on
+func (e AlertEventV2) flatten() AlertEvent {
+	return AlertEvent{AlertCommon: e.Common, AlertModuleData: e.ModuleData}
+}
+
+// receiveAlertV2 godoc
+// @Summary Receive and store a v2 alert event
+// @Description Accepts the nested monitor-service v2 payload, with the shared fields under common and the module-specific ones under module_data, and stores it exactly like POST /alerts.
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param alert body AlertEventV2 true "Alert Event (v2)"
+// @Param X-Ingest-Key header string false "Shared secret for the event's module, required when INGEST_KEY or INGEST_KEYS covers it"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /v2/alerts [post]
+func receiveAlertV2(c *gin.Context) {
+	var payload AlertEventV2
+	if !bindAlertBody(c, &payload) {
+		return
+	}
+	event := payload.flatten()
+	storeEvent(c, &event)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+)
+
+// redisInsertColumns is how many values an insert into redis_alerts binds: every Alert
+// column but labels, which an event without labels inserts as NULL, plus the redis columns
+const redisInsertColumns = 29
+
+func TestReceiveAlertSchemaVersions(t *testing.T) {
+	loadTestConfig(t, nil)
+	ts := time.Now().UTC().Format(time.RFC3339)
+	flat := `{"timestamp":"` + ts + `","module":"redis","service_name":"cache","event_name":"big_keys","host_ip":"10.0.0.1","big_keys_count":12}`
+	nested := `{"common":{"timestamp":"` + ts + `","module":"redis","service_name":"cache","event_name":"big_keys","host_ip":"10.0.0.1"},"module_data":{"big_keys_count":12}}`
+
+	tests := []struct {
+		name    string
+		path    string
+		version string
+		body    string
+		status  int
+	}{
+		{"flat v1", "/api/alerts", "", flat, http.StatusOK},
+		{"nested v2 endpoint", "/api/v2/alerts", "", nested, http.StatusOK},
+		{"nested v2 by header", "/api/alerts", "2", nested, http.StatusOK},
+		{"nested without header", "/api/alerts", "", nested, http.StatusBadRequest},
+		{"unknown version", "/api/alerts", "3", flat, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mockDB(t)
+			if tt.status == http.StatusOK {
+				mock.ExpectQuery("FROM `host_mutes`").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				// Both shapes store the module field big_keys_count, the second to last column
+				args := make([]driver.Value, redisInsertColumns)
+				for i := range args {
+					args[i] = sqlmock.AnyArg()
+				}
+				args[len(args)-2] = 12
+				mock.ExpectExec("INSERT INTO `redis_alerts` .*`big_keys_count`,`failed_nodes`\\)").
+					WithArgs(args...).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+			r := gin.New()
+			r.POST("/api/alerts", receiveAlert)
+			r.POST("/api/v2/alerts", receiveAlertV2)
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.version != "" {
+				req.Header.Set(schemaVersionHeader, tt.version)
+			}
+			if w := serveRequest(r, req); w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+		})
+	}
+}